@@ -0,0 +1,29 @@
+// Package mtconnectv1 содержит версионированную схему сообщений MTConnect Streamer:
+// описание Protobuf (mtconnect.proto), сгенерированный по нему код и эквивалентные схемы Avro,
+// а также описание gRPC API сервиса (service.proto).
+package mtconnectv1
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative mtconnect/v1/mtconnect.proto
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative mtconnect/v1/service.proto
+
+// ProtoSchema - исходный текст mtconnect.proto, регистрируемый в schema registry
+//
+//go:embed mtconnect.proto
+var ProtoSchema string
+
+//go:embed *.avsc
+var avroSchemas embed.FS
+
+// AvroSchema возвращает схему Avro для записи с указанным именем (например, MachineData)
+func AvroSchema(record string) (string, error) {
+	schema, err := avroSchemas.ReadFile(record + ".avsc")
+	if err != nil {
+		return "", fmt.Errorf("схема Avro для '%s' не найдена", record)
+	}
+	return string(schema), nil
+}
@@ -0,0 +1,681 @@
+// gRPC API MTConnect Streamer, версия 1.
+//
+// Повторяет REST API /api/v1: управление подключениями и опросом, текущее
+// состояние станка и поток событий реального времени. Сообщения данных
+// (MachineData, AlarmEvent, LifecycleEvent) берутся из mtconnect.proto.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.0
+// source: mtconnect/v1/service.proto
+
+package mtconnectv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StreamerService_CreateConnection_FullMethodName         = "/mtconnect.v1.StreamerService/CreateConnection"
+	StreamerService_ListConnections_FullMethodName          = "/mtconnect.v1.StreamerService/ListConnections"
+	StreamerService_GetConnection_FullMethodName            = "/mtconnect.v1.StreamerService/GetConnection"
+	StreamerService_UpdateConnection_FullMethodName         = "/mtconnect.v1.StreamerService/UpdateConnection"
+	StreamerService_DeleteConnection_FullMethodName         = "/mtconnect.v1.StreamerService/DeleteConnection"
+	StreamerService_CheckConnection_FullMethodName          = "/mtconnect.v1.StreamerService/CheckConnection"
+	StreamerService_BulkCreateConnections_FullMethodName    = "/mtconnect.v1.StreamerService/BulkCreateConnections"
+	StreamerService_ExportConnections_FullMethodName        = "/mtconnect.v1.StreamerService/ExportConnections"
+	StreamerService_DiscoverDevices_FullMethodName          = "/mtconnect.v1.StreamerService/DiscoverDevices"
+	StreamerService_ConnectDiscoveredDevices_FullMethodName = "/mtconnect.v1.StreamerService/ConnectDiscoveredDevices"
+	StreamerService_StartPolling_FullMethodName             = "/mtconnect.v1.StreamerService/StartPolling"
+	StreamerService_StopPolling_FullMethodName              = "/mtconnect.v1.StreamerService/StopPolling"
+	StreamerService_GetCurrentData_FullMethodName           = "/mtconnect.v1.StreamerService/GetCurrentData"
+	StreamerService_GetActiveAlarms_FullMethodName          = "/mtconnect.v1.StreamerService/GetActiveAlarms"
+	StreamerService_Subscribe_FullMethodName                = "/mtconnect.v1.StreamerService/Subscribe"
+)
+
+// StreamerServiceClient is the client API for StreamerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StreamerServiceClient interface {
+	// Управление подключениями
+	CreateConnection(ctx context.Context, in *CreateConnectionRequest, opts ...grpc.CallOption) (*Connection, error)
+	ListConnections(ctx context.Context, in *ListConnectionsRequest, opts ...grpc.CallOption) (*ListConnectionsResponse, error)
+	GetConnection(ctx context.Context, in *GetConnectionRequest, opts ...grpc.CallOption) (*Connection, error)
+	// UpdateConnection заново проверяет измененную конфигурацию по /probe и перезапускает опрос сессии
+	UpdateConnection(ctx context.Context, in *UpdateConnectionRequest, opts ...grpc.CallOption) (*Connection, error)
+	DeleteConnection(ctx context.Context, in *DeleteConnectionRequest, opts ...grpc.CallOption) (*DeleteConnectionResponse, error)
+	CheckConnection(ctx context.Context, in *CheckConnectionRequest, opts ...grpc.CallOption) (*Connection, error)
+	// BulkCreateConnections создает пакет подключений, запрашивая /probe каждого агента один раз
+	BulkCreateConnections(ctx context.Context, in *BulkCreateConnectionsRequest, opts ...grpc.CallOption) (*BulkCreateConnectionsResponse, error)
+	// ExportConnections возвращает конфигурации подключений для переноса на другой экземпляр
+	ExportConnections(ctx context.Context, in *ExportConnectionsRequest, opts ...grpc.CallOption) (*ExportConnectionsResponse, error)
+	// Обнаружение устройств агента
+	DiscoverDevices(ctx context.Context, in *DiscoverDevicesRequest, opts ...grpc.CallOption) (*DiscoverDevicesResponse, error)
+	// ConnectDiscoveredDevices создает подключения для выбранных или всех устройств агента
+	ConnectDiscoveredDevices(ctx context.Context, in *ConnectDiscoveredDevicesRequest, opts ...grpc.CallOption) (*BulkCreateConnectionsResponse, error)
+	// Управление опросом всех подключений
+	StartPolling(ctx context.Context, in *StartPollingRequest, opts ...grpc.CallOption) (*StartPollingResponse, error)
+	StopPolling(ctx context.Context, in *StopPollingRequest, opts ...grpc.CallOption) (*StopPollingResponse, error)
+	// Текущее состояние
+	GetCurrentData(ctx context.Context, in *GetCurrentDataRequest, opts ...grpc.CallOption) (*MachineData, error)
+	GetActiveAlarms(ctx context.Context, in *GetActiveAlarmsRequest, opts ...grpc.CallOption) (*GetActiveAlarmsResponse, error)
+	// Subscribe передает MachineData, события аварий и события жизненного цикла по мере их появления
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamEvent], error)
+}
+
+type streamerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStreamerServiceClient(cc grpc.ClientConnInterface) StreamerServiceClient {
+	return &streamerServiceClient{cc}
+}
+
+func (c *streamerServiceClient) CreateConnection(ctx context.Context, in *CreateConnectionRequest, opts ...grpc.CallOption) (*Connection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Connection)
+	err := c.cc.Invoke(ctx, StreamerService_CreateConnection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) ListConnections(ctx context.Context, in *ListConnectionsRequest, opts ...grpc.CallOption) (*ListConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConnectionsResponse)
+	err := c.cc.Invoke(ctx, StreamerService_ListConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) GetConnection(ctx context.Context, in *GetConnectionRequest, opts ...grpc.CallOption) (*Connection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Connection)
+	err := c.cc.Invoke(ctx, StreamerService_GetConnection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) UpdateConnection(ctx context.Context, in *UpdateConnectionRequest, opts ...grpc.CallOption) (*Connection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Connection)
+	err := c.cc.Invoke(ctx, StreamerService_UpdateConnection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) DeleteConnection(ctx context.Context, in *DeleteConnectionRequest, opts ...grpc.CallOption) (*DeleteConnectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteConnectionResponse)
+	err := c.cc.Invoke(ctx, StreamerService_DeleteConnection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) CheckConnection(ctx context.Context, in *CheckConnectionRequest, opts ...grpc.CallOption) (*Connection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Connection)
+	err := c.cc.Invoke(ctx, StreamerService_CheckConnection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) BulkCreateConnections(ctx context.Context, in *BulkCreateConnectionsRequest, opts ...grpc.CallOption) (*BulkCreateConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkCreateConnectionsResponse)
+	err := c.cc.Invoke(ctx, StreamerService_BulkCreateConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) ExportConnections(ctx context.Context, in *ExportConnectionsRequest, opts ...grpc.CallOption) (*ExportConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportConnectionsResponse)
+	err := c.cc.Invoke(ctx, StreamerService_ExportConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) DiscoverDevices(ctx context.Context, in *DiscoverDevicesRequest, opts ...grpc.CallOption) (*DiscoverDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscoverDevicesResponse)
+	err := c.cc.Invoke(ctx, StreamerService_DiscoverDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) ConnectDiscoveredDevices(ctx context.Context, in *ConnectDiscoveredDevicesRequest, opts ...grpc.CallOption) (*BulkCreateConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkCreateConnectionsResponse)
+	err := c.cc.Invoke(ctx, StreamerService_ConnectDiscoveredDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) StartPolling(ctx context.Context, in *StartPollingRequest, opts ...grpc.CallOption) (*StartPollingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartPollingResponse)
+	err := c.cc.Invoke(ctx, StreamerService_StartPolling_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) StopPolling(ctx context.Context, in *StopPollingRequest, opts ...grpc.CallOption) (*StopPollingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopPollingResponse)
+	err := c.cc.Invoke(ctx, StreamerService_StopPolling_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) GetCurrentData(ctx context.Context, in *GetCurrentDataRequest, opts ...grpc.CallOption) (*MachineData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MachineData)
+	err := c.cc.Invoke(ctx, StreamerService_GetCurrentData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) GetActiveAlarms(ctx context.Context, in *GetActiveAlarmsRequest, opts ...grpc.CallOption) (*GetActiveAlarmsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetActiveAlarmsResponse)
+	err := c.cc.Invoke(ctx, StreamerService_GetActiveAlarms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamerServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StreamerService_ServiceDesc.Streams[0], StreamerService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, StreamEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StreamerService_SubscribeClient = grpc.ServerStreamingClient[StreamEvent]
+
+// StreamerServiceServer is the server API for StreamerService service.
+// All implementations must embed UnimplementedStreamerServiceServer
+// for forward compatibility.
+type StreamerServiceServer interface {
+	// Управление подключениями
+	CreateConnection(context.Context, *CreateConnectionRequest) (*Connection, error)
+	ListConnections(context.Context, *ListConnectionsRequest) (*ListConnectionsResponse, error)
+	GetConnection(context.Context, *GetConnectionRequest) (*Connection, error)
+	// UpdateConnection заново проверяет измененную конфигурацию по /probe и перезапускает опрос сессии
+	UpdateConnection(context.Context, *UpdateConnectionRequest) (*Connection, error)
+	DeleteConnection(context.Context, *DeleteConnectionRequest) (*DeleteConnectionResponse, error)
+	CheckConnection(context.Context, *CheckConnectionRequest) (*Connection, error)
+	// BulkCreateConnections создает пакет подключений, запрашивая /probe каждого агента один раз
+	BulkCreateConnections(context.Context, *BulkCreateConnectionsRequest) (*BulkCreateConnectionsResponse, error)
+	// ExportConnections возвращает конфигурации подключений для переноса на другой экземпляр
+	ExportConnections(context.Context, *ExportConnectionsRequest) (*ExportConnectionsResponse, error)
+	// Обнаружение устройств агента
+	DiscoverDevices(context.Context, *DiscoverDevicesRequest) (*DiscoverDevicesResponse, error)
+	// ConnectDiscoveredDevices создает подключения для выбранных или всех устройств агента
+	ConnectDiscoveredDevices(context.Context, *ConnectDiscoveredDevicesRequest) (*BulkCreateConnectionsResponse, error)
+	// Управление опросом всех подключений
+	StartPolling(context.Context, *StartPollingRequest) (*StartPollingResponse, error)
+	StopPolling(context.Context, *StopPollingRequest) (*StopPollingResponse, error)
+	// Текущее состояние
+	GetCurrentData(context.Context, *GetCurrentDataRequest) (*MachineData, error)
+	GetActiveAlarms(context.Context, *GetActiveAlarmsRequest) (*GetActiveAlarmsResponse, error)
+	// Subscribe передает MachineData, события аварий и события жизненного цикла по мере их появления
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[StreamEvent]) error
+	mustEmbedUnimplementedStreamerServiceServer()
+}
+
+// UnimplementedStreamerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStreamerServiceServer struct{}
+
+func (UnimplementedStreamerServiceServer) CreateConnection(context.Context, *CreateConnectionRequest) (*Connection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConnection not implemented")
+}
+func (UnimplementedStreamerServiceServer) ListConnections(context.Context, *ListConnectionsRequest) (*ListConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConnections not implemented")
+}
+func (UnimplementedStreamerServiceServer) GetConnection(context.Context, *GetConnectionRequest) (*Connection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnection not implemented")
+}
+func (UnimplementedStreamerServiceServer) UpdateConnection(context.Context, *UpdateConnectionRequest) (*Connection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConnection not implemented")
+}
+func (UnimplementedStreamerServiceServer) DeleteConnection(context.Context, *DeleteConnectionRequest) (*DeleteConnectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConnection not implemented")
+}
+func (UnimplementedStreamerServiceServer) CheckConnection(context.Context, *CheckConnectionRequest) (*Connection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckConnection not implemented")
+}
+func (UnimplementedStreamerServiceServer) BulkCreateConnections(context.Context, *BulkCreateConnectionsRequest) (*BulkCreateConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCreateConnections not implemented")
+}
+func (UnimplementedStreamerServiceServer) ExportConnections(context.Context, *ExportConnectionsRequest) (*ExportConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportConnections not implemented")
+}
+func (UnimplementedStreamerServiceServer) DiscoverDevices(context.Context, *DiscoverDevicesRequest) (*DiscoverDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverDevices not implemented")
+}
+func (UnimplementedStreamerServiceServer) ConnectDiscoveredDevices(context.Context, *ConnectDiscoveredDevicesRequest) (*BulkCreateConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectDiscoveredDevices not implemented")
+}
+func (UnimplementedStreamerServiceServer) StartPolling(context.Context, *StartPollingRequest) (*StartPollingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartPolling not implemented")
+}
+func (UnimplementedStreamerServiceServer) StopPolling(context.Context, *StopPollingRequest) (*StopPollingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopPolling not implemented")
+}
+func (UnimplementedStreamerServiceServer) GetCurrentData(context.Context, *GetCurrentDataRequest) (*MachineData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentData not implemented")
+}
+func (UnimplementedStreamerServiceServer) GetActiveAlarms(context.Context, *GetActiveAlarmsRequest) (*GetActiveAlarmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveAlarms not implemented")
+}
+func (UnimplementedStreamerServiceServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[StreamEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedStreamerServiceServer) mustEmbedUnimplementedStreamerServiceServer() {}
+func (UnimplementedStreamerServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeStreamerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StreamerServiceServer will
+// result in compilation errors.
+type UnsafeStreamerServiceServer interface {
+	mustEmbedUnimplementedStreamerServiceServer()
+}
+
+func RegisterStreamerServiceServer(s grpc.ServiceRegistrar, srv StreamerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStreamerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StreamerService_ServiceDesc, srv)
+}
+
+func _StreamerService_CreateConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).CreateConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_CreateConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).CreateConnection(ctx, req.(*CreateConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_ListConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).ListConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_ListConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).ListConnections(ctx, req.(*ListConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_GetConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).GetConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_GetConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).GetConnection(ctx, req.(*GetConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_UpdateConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).UpdateConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_UpdateConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).UpdateConnection(ctx, req.(*UpdateConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_DeleteConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).DeleteConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_DeleteConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).DeleteConnection(ctx, req.(*DeleteConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_CheckConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).CheckConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_CheckConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).CheckConnection(ctx, req.(*CheckConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_BulkCreateConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreateConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).BulkCreateConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_BulkCreateConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).BulkCreateConnections(ctx, req.(*BulkCreateConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_ExportConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).ExportConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_ExportConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).ExportConnections(ctx, req.(*ExportConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_DiscoverDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).DiscoverDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_DiscoverDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).DiscoverDevices(ctx, req.(*DiscoverDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_ConnectDiscoveredDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectDiscoveredDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).ConnectDiscoveredDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_ConnectDiscoveredDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).ConnectDiscoveredDevices(ctx, req.(*ConnectDiscoveredDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_StartPolling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPollingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).StartPolling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_StartPolling_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).StartPolling(ctx, req.(*StartPollingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_StopPolling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopPollingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).StopPolling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_StopPolling_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).StopPolling(ctx, req.(*StopPollingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_GetCurrentData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).GetCurrentData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_GetCurrentData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).GetCurrentData(ctx, req.(*GetCurrentDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_GetActiveAlarms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActiveAlarmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamerServiceServer).GetActiveAlarms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamerService_GetActiveAlarms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamerServiceServer).GetActiveAlarms(ctx, req.(*GetActiveAlarmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamerService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StreamerServiceServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, StreamEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StreamerService_SubscribeServer = grpc.ServerStreamingServer[StreamEvent]
+
+// StreamerService_ServiceDesc is the grpc.ServiceDesc for StreamerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StreamerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mtconnect.v1.StreamerService",
+	HandlerType: (*StreamerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateConnection",
+			Handler:    _StreamerService_CreateConnection_Handler,
+		},
+		{
+			MethodName: "ListConnections",
+			Handler:    _StreamerService_ListConnections_Handler,
+		},
+		{
+			MethodName: "GetConnection",
+			Handler:    _StreamerService_GetConnection_Handler,
+		},
+		{
+			MethodName: "UpdateConnection",
+			Handler:    _StreamerService_UpdateConnection_Handler,
+		},
+		{
+			MethodName: "DeleteConnection",
+			Handler:    _StreamerService_DeleteConnection_Handler,
+		},
+		{
+			MethodName: "CheckConnection",
+			Handler:    _StreamerService_CheckConnection_Handler,
+		},
+		{
+			MethodName: "BulkCreateConnections",
+			Handler:    _StreamerService_BulkCreateConnections_Handler,
+		},
+		{
+			MethodName: "ExportConnections",
+			Handler:    _StreamerService_ExportConnections_Handler,
+		},
+		{
+			MethodName: "DiscoverDevices",
+			Handler:    _StreamerService_DiscoverDevices_Handler,
+		},
+		{
+			MethodName: "ConnectDiscoveredDevices",
+			Handler:    _StreamerService_ConnectDiscoveredDevices_Handler,
+		},
+		{
+			MethodName: "StartPolling",
+			Handler:    _StreamerService_StartPolling_Handler,
+		},
+		{
+			MethodName: "StopPolling",
+			Handler:    _StreamerService_StopPolling_Handler,
+		},
+		{
+			MethodName: "GetCurrentData",
+			Handler:    _StreamerService_GetCurrentData_Handler,
+		},
+		{
+			MethodName: "GetActiveAlarms",
+			Handler:    _StreamerService_GetActiveAlarms_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _StreamerService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mtconnect/v1/service.proto",
+}
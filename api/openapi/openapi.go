@@ -0,0 +1,9 @@
+// Package openapi содержит спецификацию OpenAPI 3 REST API /api/v1.
+package openapi
+
+import _ "embed"
+
+// Spec - спецификация в формате YAML, которую сервис отдает по GET /api/v1/openapi.yaml
+//
+//go:embed openapi.yaml
+var Spec []byte
@@ -0,0 +1,147 @@
+package grpcapi
+
+import (
+	mtconnectv1 "MTConnect/api/mtconnect/v1"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// methodRoles - роль, необходимая для вызова метода; распределение совпадает с REST API
+var methodRoles = map[string]entities.Role{
+	mtconnectv1.StreamerService_CreateConnection_FullMethodName:         entities.RoleAdmin,
+	mtconnectv1.StreamerService_ListConnections_FullMethodName:          entities.RoleViewer,
+	mtconnectv1.StreamerService_GetConnection_FullMethodName:            entities.RoleViewer,
+	mtconnectv1.StreamerService_UpdateConnection_FullMethodName:         entities.RoleAdmin,
+	mtconnectv1.StreamerService_DeleteConnection_FullMethodName:         entities.RoleAdmin,
+	mtconnectv1.StreamerService_CheckConnection_FullMethodName:          entities.RoleViewer,
+	mtconnectv1.StreamerService_BulkCreateConnections_FullMethodName:    entities.RoleAdmin,
+	mtconnectv1.StreamerService_ExportConnections_FullMethodName:        entities.RoleViewer,
+	mtconnectv1.StreamerService_DiscoverDevices_FullMethodName:          entities.RoleAdmin,
+	mtconnectv1.StreamerService_ConnectDiscoveredDevices_FullMethodName: entities.RoleAdmin,
+	mtconnectv1.StreamerService_StartPolling_FullMethodName:             entities.RoleOperator,
+	mtconnectv1.StreamerService_StopPolling_FullMethodName:              entities.RoleOperator,
+	mtconnectv1.StreamerService_GetCurrentData_FullMethodName:           entities.RoleViewer,
+	mtconnectv1.StreamerService_GetActiveAlarms_FullMethodName:          entities.RoleViewer,
+	mtconnectv1.StreamerService_Subscribe_FullMethodName:                entities.RoleViewer,
+}
+
+// AuthInterceptor проверяет учетные данные из метаданных запроса (x-api-key или authorization: Bearer)
+// и записывает вызовы, требующие роль operator или admin, в журнал аудита
+type AuthInterceptor struct {
+	auth  interfaces.Authenticator
+	audit interfaces.AuditLog
+}
+
+func NewAuthInterceptor(auth interfaces.Authenticator, audit interfaces.AuditLog) *AuthInterceptor {
+	return &AuthInterceptor{auth: auth, audit: audit}
+}
+
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		required := requiredRole(info.FullMethod)
+		principal, err := i.authorize(ctx, required)
+
+		var record *entities.AuditRecord
+		if required != entities.RoleViewer {
+			record = newAuditRecord(ctx, info.FullMethod, principal, req)
+		}
+		var resp interface{}
+		if err == nil {
+			resp, err = handler(ctx, req)
+		}
+		if record != nil {
+			record.Result = auditResult(err)
+			i.audit.Record(*record)
+		}
+		return resp, err
+	}
+}
+
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, err := i.authorize(stream.Context(), requiredRole(info.FullMethod)); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func (i *AuthInterceptor) authorize(ctx context.Context, required entities.Role) (entities.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	apiKey := firstValue(md, "x-api-key")
+	bearerToken := ""
+	if header := firstValue(md, "authorization"); len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		bearerToken = strings.TrimSpace(header[7:])
+	}
+	principal, err := i.auth.Authenticate(apiKey, bearerToken)
+	if err != nil {
+		return principal, statusError(err)
+	}
+	if !principal.Role.Allows(required) {
+		return principal, statusError(entities.NewError(entities.ErrorCodePermissionDenied, "роль '%s' не позволяет выполнить запрос, требуется '%s'", principal.Role, required))
+	}
+	return principal, nil
+}
+
+// requiredRole возвращает роль метода; для неизвестных методов требуется admin
+func requiredRole(fullMethod string) entities.Role {
+	if role, ok := methodRoles[fullMethod]; ok {
+		return role
+	}
+	return entities.RoleAdmin
+}
+
+func newAuditRecord(ctx context.Context, fullMethod string, principal entities.Principal, req interface{}) *entities.AuditRecord {
+	record := &entities.AuditRecord{
+		Time:       time.Now().UTC(),
+		Subject:    principal.Subject,
+		Role:       principal.Role,
+		AuthMethod: principal.AuthMethod,
+		Transport:  "grpc",
+		Action:     fullMethod,
+	}
+	if record.Subject == "" {
+		record.Subject = "unauthenticated"
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		record.RemoteAddr = p.Addr.String()
+	}
+	if message, ok := req.(proto.Message); ok {
+		if body, err := protojson.Marshal(message); err == nil {
+			record.Request = entities.RedactAuditRequest(body)
+		}
+	}
+	return record
+}
+
+// auditResult возвращает OK или код ошибки из ErrorInfo, как в журнале аудита REST API
+func auditResult(err error) string {
+	if err == nil {
+		return "OK"
+	}
+	st := status.Convert(err)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == errorDomain {
+			return info.Reason
+		}
+	}
+	return st.Code().String()
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
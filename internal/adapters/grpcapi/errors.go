@@ -0,0 +1,60 @@
+package grpcapi
+
+import (
+	"MTConnect/internal/domain/entities"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain - домен ErrorInfo, в поле Reason которого передается код ошибки, как в REST API
+const errorDomain = "mtconnect"
+
+// errorCodes сопоставляет коды ошибок предметной области с кодами gRPC
+var errorCodes = map[entities.ErrorCode]codes.Code{
+	entities.ErrorCodeInvalidArgument:  codes.InvalidArgument,
+	entities.ErrorCodeNotFound:         codes.NotFound,
+	entities.ErrorCodeAlreadyExists:    codes.AlreadyExists,
+	entities.ErrorCodeAgentUnreachable: codes.Unavailable,
+	entities.ErrorCodeProbeInvalid:     codes.FailedPrecondition,
+	entities.ErrorCodeModelNotFound:    codes.NotFound,
+	entities.ErrorCodeDeviceAmbiguous:  codes.InvalidArgument,
+	entities.ErrorCodeUnauthenticated:  codes.Unauthenticated,
+	entities.ErrorCodePermissionDenied: codes.PermissionDenied,
+	entities.ErrorCodeUnavailable:      codes.Unavailable,
+	entities.ErrorCodeInternal:         codes.Internal,
+}
+
+// statusError преобразует ошибку в статус gRPC с деталями ErrorInfo. Кандидаты неоднозначного выбора
+// устройства передаются в метаданных: имена в "candidates", UUID в "candidateUuids" через запятую.
+func statusError(err error) error {
+	code := entities.ErrorCodeOf(err)
+	grpcCode, ok := errorCodes[code]
+	if !ok {
+		grpcCode = codes.Internal
+	}
+	info := &errdetails.ErrorInfo{Reason: string(code), Domain: errorDomain}
+	var ambiguity *entities.DeviceAmbiguityError
+	if errors.As(err, &ambiguity) {
+		names := make([]string, 0, len(ambiguity.Candidates))
+		uuids := make([]string, 0, len(ambiguity.Candidates))
+		for _, candidate := range ambiguity.Candidates {
+			names = append(names, candidate.Name)
+			uuids = append(uuids, candidate.UUID)
+		}
+		info.Metadata = map[string]string{"candidates": strings.Join(names, ","), "candidateUuids": strings.Join(uuids, ",")}
+	}
+	st := status.New(grpcCode, err.Error())
+	if detailed, detailsErr := st.WithDetails(info); detailsErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// invalidArgument возвращает ошибку проверки параметров запроса
+func invalidArgument(format string, args ...interface{}) error {
+	return statusError(entities.NewError(entities.ErrorCodeInvalidArgument, format, args...))
+}
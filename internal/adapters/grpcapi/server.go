@@ -0,0 +1,410 @@
+package grpcapi
+
+import (
+	mtconnectv1 "MTConnect/api/mtconnect/v1"
+	"MTConnect/internal/adapters/schema"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var grpcLog = logging.Logger(logging.ComponentGRPC)
+
+// defaultPollingInterval - интервал опроса, если клиент его не указал (как в REST API)
+const defaultPollingInterval = 1000 * time.Millisecond
+
+// Server реализует gRPC API mtconnect.v1.StreamerService поверх тех же use cases, что и REST API
+type Server struct {
+	mtconnectv1.UnimplementedStreamerServiceServer
+	usecase interfaces.Usecases
+
+	// shutdown закрывается при остановке сервиса и завершает открытые потоки Subscribe
+	shutdown  chan struct{}
+	closeOnce sync.Once
+}
+
+func NewServer(usecase interfaces.Usecases) *Server {
+	return &Server{usecase: usecase, shutdown: make(chan struct{})}
+}
+
+// Close завершает открытые потоки Subscribe, чтобы gRPC-сервер мог остановиться штатно
+func (s *Server) Close() {
+	s.closeOnce.Do(func() { close(s.shutdown) })
+}
+
+// --- Управление подключениями ---
+
+func (s *Server) CreateConnection(ctx context.Context, req *mtconnectv1.CreateConnectionRequest) (*mtconnectv1.Connection, error) {
+	connRequest := connectionRequestFromProto(req)
+	if err := connRequest.Validate(); err != nil {
+		return nil, statusError(err)
+	}
+	connInfo, err := s.usecase.CreateConnection(connRequest)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return connectionToProto(connInfo), nil
+}
+
+func (s *Server) ListConnections(ctx context.Context, req *mtconnectv1.ListConnectionsRequest) (*mtconnectv1.ListConnectionsResponse, error) {
+	page, err := s.usecase.ListConnections(entities.ConnectionQuery{
+		MachineID:    req.GetMachineId(),
+		Manufacturer: req.GetManufacturer(),
+		Model:        req.GetModel(),
+		Healthy:      req.Healthy,
+		Sort:         req.GetSort(),
+		Limit:        int(req.GetLimit()),
+		Offset:       int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &mtconnectv1.ListConnectionsResponse{
+		Connections: make([]*mtconnectv1.Connection, 0, len(page.Items)),
+		Total:       int32(page.Total),
+	}
+	for _, conn := range page.Items {
+		resp.Connections = append(resp.Connections, connectionToProto(conn))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetConnection(ctx context.Context, req *mtconnectv1.GetConnectionRequest) (*mtconnectv1.Connection, error) {
+	if req.GetSessionId() == "" {
+		return nil, invalidArgument("поле session_id обязательно")
+	}
+	connInfo, err := s.usecase.GetConnection(req.GetSessionId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return connectionToProto(connInfo), nil
+}
+
+func (s *Server) UpdateConnection(ctx context.Context, req *mtconnectv1.UpdateConnectionRequest) (*mtconnectv1.Connection, error) {
+	if req.GetSessionId() == "" {
+		return nil, invalidArgument("поле session_id обязательно")
+	}
+	connInfo, err := s.usecase.UpdateConnection(req.GetSessionId(), entities.ConnectionPatch{
+		EndpointURL:  req.EndpointUrl,
+		Model:        req.Model,
+		Manufacturer: req.Manufacturer,
+		Match:        selectorFromProto(req.GetMatch()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return connectionToProto(connInfo), nil
+}
+
+func (s *Server) DeleteConnection(ctx context.Context, req *mtconnectv1.DeleteConnectionRequest) (*mtconnectv1.DeleteConnectionResponse, error) {
+	if req.GetSessionId() == "" {
+		return nil, invalidArgument("поле session_id обязательно")
+	}
+	if err := s.usecase.DeleteConnection(req.GetSessionId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &mtconnectv1.DeleteConnectionResponse{}, nil
+}
+
+func (s *Server) CheckConnection(ctx context.Context, req *mtconnectv1.CheckConnectionRequest) (*mtconnectv1.Connection, error) {
+	if req.GetSessionId() == "" {
+		return nil, invalidArgument("поле session_id обязательно")
+	}
+	connInfo, err := s.usecase.CheckConnection(req.GetSessionId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return connectionToProto(connInfo), nil
+}
+
+func (s *Server) BulkCreateConnections(ctx context.Context, req *mtconnectv1.BulkCreateConnectionsRequest) (*mtconnectv1.BulkCreateConnectionsResponse, error) {
+	bulk := entities.BulkConnectionRequest{
+		Connections: make([]entities.ConnectionRequest, 0, len(req.GetConnections())),
+		Concurrency: int(req.GetConcurrency()),
+	}
+	for _, item := range req.GetConnections() {
+		bulk.Connections = append(bulk.Connections, connectionRequestFromProto(item))
+	}
+	results, err := s.usecase.BulkCreateConnections(bulk)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return bulkResultsToProto(results), nil
+}
+
+func (s *Server) ExportConnections(ctx context.Context, req *mtconnectv1.ExportConnectionsRequest) (*mtconnectv1.ExportConnectionsResponse, error) {
+	export, err := s.usecase.ExportConnections(entities.ConnectionQuery{
+		MachineID:    req.GetMachineId(),
+		Manufacturer: req.GetManufacturer(),
+		Model:        req.GetModel(),
+		Healthy:      req.Healthy,
+		Sort:         req.GetSort(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &mtconnectv1.ExportConnectionsResponse{Connections: make([]*mtconnectv1.CreateConnectionRequest, 0, len(export.Connections))}
+	for _, item := range export.Connections {
+		resp.Connections = append(resp.Connections, connectionRequestToProto(item))
+	}
+	return resp, nil
+}
+
+// --- Обнаружение устройств ---
+
+func (s *Server) DiscoverDevices(ctx context.Context, req *mtconnectv1.DiscoverDevicesRequest) (*mtconnectv1.DiscoverDevicesResponse, error) {
+	devices, err := s.usecase.DiscoverDevices(req.GetEndpointUrl())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &mtconnectv1.DiscoverDevicesResponse{Devices: make([]*mtconnectv1.DiscoveredDevice, 0, len(devices))}
+	for _, device := range devices {
+		components := make(map[string]int32, len(device.Components))
+		for componentType, count := range device.Components {
+			components[componentType] = int32(count)
+		}
+		resp.Devices = append(resp.Devices, &mtconnectv1.DiscoveredDevice{
+			Name:         device.Name,
+			Uuid:         device.UUID,
+			Id:           device.ID,
+			Manufacturer: device.Manufacturer,
+			Model:        device.Model,
+			SerialNumber: device.SerialNumber,
+			Description:  device.Description,
+			Components:   components,
+			DataItems:    int32(device.DataItems),
+			SessionId:    device.SessionID,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) ConnectDiscoveredDevices(ctx context.Context, req *mtconnectv1.ConnectDiscoveredDevicesRequest) (*mtconnectv1.BulkCreateConnectionsResponse, error) {
+	results, err := s.usecase.ConnectDiscoveredDevices(entities.DiscoverConnectRequest{
+		EndpointURL: req.GetEndpointUrl(),
+		Devices:     req.GetDevices(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return bulkResultsToProto(results), nil
+}
+
+// --- Управление опросом ---
+
+func (s *Server) StartPolling(ctx context.Context, req *mtconnectv1.StartPollingRequest) (*mtconnectv1.StartPollingResponse, error) {
+	if req.GetIntervalMs() < 0 {
+		return nil, invalidArgument("интервал опроса не может быть отрицательным")
+	}
+	interval := time.Duration(req.GetIntervalMs()) * time.Millisecond
+	if interval == 0 {
+		interval = defaultPollingInterval
+	}
+	if err := s.usecase.StartPolling(interval); err != nil {
+		return nil, statusError(err)
+	}
+	return &mtconnectv1.StartPollingResponse{}, nil
+}
+
+func (s *Server) StopPolling(ctx context.Context, req *mtconnectv1.StopPollingRequest) (*mtconnectv1.StopPollingResponse, error) {
+	if err := s.usecase.StopPolling(); err != nil {
+		return nil, statusError(err)
+	}
+	return &mtconnectv1.StopPollingResponse{}, nil
+}
+
+// --- Текущее состояние ---
+
+func (s *Server) GetCurrentData(ctx context.Context, req *mtconnectv1.GetCurrentDataRequest) (*mtconnectv1.MachineData, error) {
+	data, err := s.usecase.GetCurrentData(req.GetSessionId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	message, err := schema.ToMessage(data)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return message.(*mtconnectv1.MachineData), nil
+}
+
+func (s *Server) GetActiveAlarms(ctx context.Context, req *mtconnectv1.GetActiveAlarmsRequest) (*mtconnectv1.GetActiveAlarmsResponse, error) {
+	alarms, err := s.usecase.GetActiveAlarms(req.GetSessionId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &mtconnectv1.GetActiveAlarmsResponse{Alarms: make([]*mtconnectv1.ActiveAlarm, 0, len(alarms))}
+	for _, alarm := range alarms {
+		resp.Alarms = append(resp.Alarms, activeAlarmToProto(alarm))
+	}
+	return resp, nil
+}
+
+// --- Поток реального времени ---
+
+// Subscribe передает события из той же подписки, что SSE и WebSocket. Клиент, не успевающий
+// получать события, отключается с кодом RESOURCE_EXHAUSTED и может возобновить поток по last_event_id
+func (s *Server) Subscribe(req *mtconnectv1.SubscribeRequest, stream mtconnectv1.StreamerService_SubscribeServer) error {
+	filter := entities.StreamFilter{
+		SessionIDs: req.GetSessionIds(),
+		Events:     req.GetEvents(),
+		Fields:     req.GetFields(),
+	}
+	sub, err := s.usecase.Subscribe(filter, req.GetLastEventId())
+	if err != nil {
+		return statusError(err)
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.shutdown:
+			return status.Error(codes.Unavailable, "сервис останавливается")
+		case event, open := <-sub.Events():
+			if !open {
+				if err := sub.Err(); err != nil {
+					return status.Error(codes.ResourceExhausted, err.Error())
+				}
+				return status.Error(codes.Unavailable, "поток закрыт")
+			}
+			message, err := streamEventToProto(event)
+			if err != nil {
+				grpcLog.Error("не удалось преобразовать событие", "eventId", event.ID, logging.Err(err))
+				continue
+			}
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// --- Преобразование в сообщения mtconnect.v1 ---
+
+func bulkResultsToProto(results []entities.BulkConnectionResult) *mtconnectv1.BulkCreateConnectionsResponse {
+	resp := &mtconnectv1.BulkCreateConnectionsResponse{Results: make([]*mtconnectv1.BulkConnectionResult, 0, len(results))}
+	for _, result := range results {
+		item := &mtconnectv1.BulkConnectionResult{
+			Index:   int32(result.Index),
+			Request: connectionRequestToProto(result.Request),
+			Code:    string(result.Code),
+			Message: result.Message,
+		}
+		if result.Connection != nil {
+			item.Connection = connectionToProto(result.Connection)
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp
+}
+
+func connectionRequestToProto(req entities.ConnectionRequest) *mtconnectv1.CreateConnectionRequest {
+	return &mtconnectv1.CreateConnectionRequest{
+		EndpointUrl:  req.EndpointURL,
+		Model:        req.Model,
+		Manufacturer: req.Manufacturer,
+		Match:        selectorToProto(req.Match),
+	}
+}
+
+func connectionRequestFromProto(req *mtconnectv1.CreateConnectionRequest) entities.ConnectionRequest {
+	return entities.ConnectionRequest{
+		EndpointURL:  req.GetEndpointUrl(),
+		Model:        req.GetModel(),
+		Manufacturer: req.GetManufacturer(),
+		Match:        selectorFromProto(req.GetMatch()),
+	}
+}
+
+// selectorFromProto сохраняет различие между отсутствующим и пустым селектором: пустой селектор
+// в UpdateConnection удаляет match
+func selectorFromProto(match *mtconnectv1.DeviceSelector) *entities.DeviceSelector {
+	if match == nil {
+		return nil
+	}
+	return &entities.DeviceSelector{
+		UUID:         match.GetUuid(),
+		Name:         match.GetName(),
+		SerialNumber: match.GetSerialNumber(),
+		Model:        match.GetModel(),
+		Regex:        match.GetRegex(),
+	}
+}
+
+func selectorToProto(match *entities.DeviceSelector) *mtconnectv1.DeviceSelector {
+	if match == nil {
+		return nil
+	}
+	return &mtconnectv1.DeviceSelector{
+		Uuid:         match.UUID,
+		Name:         match.Name,
+		SerialNumber: match.SerialNumber,
+		Model:        match.Model,
+		Regex:        match.Regex,
+	}
+}
+
+func connectionToProto(conn *entities.ConnectionInfo) *mtconnectv1.Connection {
+	return &mtconnectv1.Connection{
+		SessionId:    conn.SessionID,
+		MachineId:    conn.MachineID,
+		EndpointUrl:  conn.Config.EndpointURL,
+		Model:        conn.Config.Model,
+		Manufacturer: conn.Config.Manufacturer,
+		CreatedAtMs:  conn.CreatedAt.UnixMilli(),
+		LastUsedMs:   conn.LastUsed.UnixMilli(),
+		UseCount:     conn.UseCount,
+		IsHealthy:    conn.IsHealthy,
+		Match:        selectorToProto(conn.Config.Match),
+	}
+}
+
+func activeAlarmToProto(alarm entities.ActiveAlarm) *mtconnectv1.ActiveAlarm {
+	return &mtconnectv1.ActiveAlarm{
+		DataItemId:     alarm.DataItemId,
+		NativeCode:     alarm.NativeCode,
+		NativeSeverity: alarm.NativeSeverity,
+		Qualifier:      alarm.Qualifier,
+		Level:          alarm.Level,
+		Type:           alarm.Type,
+		ComponentId:    alarm.ComponentId,
+		ComponentName:  alarm.ComponentName,
+		Message:        alarm.Message,
+		StartTimeMs:    alarm.StartTime.UnixMilli(),
+		LastUpdatedMs:  alarm.LastUpdated.UnixMilli(),
+	}
+}
+
+func streamEventToProto(event entities.StreamEvent) (*mtconnectv1.StreamEvent, error) {
+	result := &mtconnectv1.StreamEvent{
+		Id:          event.ID,
+		Event:       event.Event,
+		SessionId:   event.SessionID,
+		MachineId:   event.MachineID,
+		TimestampMs: event.Timestamp.UnixMilli(),
+	}
+	// Снимок, сокращенный фильтром fields, преобразуется так же, как для синков: невыбранные поля остаются пустыми
+	payload, err := schema.ToMessage(event.Data)
+	if err != nil {
+		return nil, err
+	}
+	switch p := payload.(type) {
+	case *mtconnectv1.MachineData:
+		result.Payload = &mtconnectv1.StreamEvent_MachineData{MachineData: p}
+	case *mtconnectv1.AlarmEvent:
+		result.Payload = &mtconnectv1.StreamEvent_Alarm{Alarm: p}
+	case *mtconnectv1.LifecycleEvent:
+		result.Payload = &mtconnectv1.StreamEvent_Lifecycle{Lifecycle: p}
+	}
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"bytes"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAuditBody - максимальный размер тела запроса, сохраняемого в журнале аудита
+const maxAuditBody = 64 << 10
+
+// AuthMiddleware проверяет учетные данные и роль клиента и записывает изменяющие запросы в журнал аудита
+type AuthMiddleware struct {
+	auth  interfaces.Authenticator
+	audit interfaces.AuditLog
+}
+
+func NewAuthMiddleware(auth interfaces.Authenticator, audit interfaces.AuditLog) *AuthMiddleware {
+	return &AuthMiddleware{auth: auth, audit: audit}
+}
+
+// Require пропускает запрос, если роль клиента не ниже required. Запросы, требующие роль
+// operator или admin, записываются в журнал аудита вместе с отказами в доступе.
+func (m *AuthMiddleware) Require(required entities.Role) gin.HandlerFunc {
+	return m.require(fixedRole(required), false)
+}
+
+// RequireCustomMethod работает как Require для маршрута пользовательских методов /:customMethod:
+// роль берется из roles по ключу "МЕТОД имя", например "POST polling:start". Для неизвестных
+// методов требуется admin, а сам обработчик ответит NOT_FOUND.
+func (m *AuthMiddleware) RequireCustomMethod(roles map[string]entities.Role) gin.HandlerFunc {
+	return m.require(func(c *gin.Context) entities.Role {
+		if role, ok := roles[c.Request.Method+" "+c.Param("customMethod")]; ok {
+			return role
+		}
+		return entities.RoleAdmin
+	}, false)
+}
+
+// RequireStream работает как Require, но дополнительно принимает ключ или токен в параметре
+// access_token: браузерные EventSource и WebSocket не позволяют задать заголовки
+func (m *AuthMiddleware) RequireStream(required entities.Role) gin.HandlerFunc {
+	return m.require(fixedRole(required), true)
+}
+
+func (m *AuthMiddleware) require(roleOf func(c *gin.Context) entities.Role, allowQueryToken bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		required := roleOf(c)
+		principal, err := m.authenticate(c, allowQueryToken)
+		if err == nil && !principal.Role.Allows(required) {
+			err = entities.NewError(entities.ErrorCodePermissionDenied, "роль '%s' не позволяет выполнить запрос, требуется '%s'", principal.Role, required)
+		}
+
+		var record *entities.AuditRecord
+		if required != entities.RoleViewer {
+			record = newAuditRecord(c, principal)
+		}
+		if err != nil {
+			if entities.ErrorCodeOf(err) == entities.ErrorCodeUnauthenticated {
+				c.Header("WWW-Authenticate", `Bearer realm="mtconnect"`)
+			}
+			respondError(c, err)
+			c.Abort()
+		} else {
+			c.Next()
+		}
+
+		if record != nil {
+			record.Result = c.GetString(errorCodeKey)
+			if record.Result == "" {
+				record.Result = "OK"
+			}
+			record.Status = c.Writer.Status()
+			m.audit.Record(*record)
+		}
+	}
+}
+
+func fixedRole(role entities.Role) func(c *gin.Context) entities.Role {
+	return func(*gin.Context) entities.Role { return role }
+}
+
+func (m *AuthMiddleware) authenticate(c *gin.Context, allowQueryToken bool) (entities.Principal, error) {
+	apiKey := c.GetHeader("X-API-Key")
+	bearerToken := ""
+	if header := c.GetHeader("Authorization"); len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		bearerToken = strings.TrimSpace(header[7:])
+	}
+	if apiKey == "" && bearerToken == "" && allowQueryToken {
+		// Ключи API не похожи на JWT: у токена три части через точку
+		if token := c.Query("access_token"); strings.Count(token, ".") == 2 {
+			bearerToken = token
+		} else {
+			apiKey = token
+		}
+	}
+	return m.auth.Authenticate(apiKey, bearerToken)
+}
+
+// newAuditRecord фиксирует клиента и запрос до выполнения обработчика; тело запроса возвращается обработчику нетронутым
+func newAuditRecord(c *gin.Context, principal entities.Principal) *entities.AuditRecord {
+	record := &entities.AuditRecord{
+		Time:       time.Now().UTC(),
+		Subject:    principal.Subject,
+		Role:       principal.Role,
+		AuthMethod: principal.AuthMethod,
+		Transport:  "http",
+		Action:     c.Request.Method + " " + c.Request.URL.RequestURI(),
+		RemoteAddr: c.ClientIP(),
+	}
+	if record.Subject == "" {
+		record.Subject = "unauthenticated"
+	}
+	if c.Request.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(c.Request.Body, maxAuditBody))
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+		record.Request = entities.RedactAuditRequest(body)
+	}
+	return record
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"MTConnect/internal/domain/entities"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorStatuses сопоставляет коды ошибок предметной области со статусами HTTP
+var errorStatuses = map[entities.ErrorCode]int{
+	entities.ErrorCodeInvalidArgument:  http.StatusBadRequest,
+	entities.ErrorCodeNotFound:         http.StatusNotFound,
+	entities.ErrorCodeAlreadyExists:    http.StatusConflict,
+	entities.ErrorCodeAgentUnreachable: http.StatusBadGateway,
+	entities.ErrorCodeProbeInvalid:     http.StatusBadGateway,
+	entities.ErrorCodeModelNotFound:    http.StatusUnprocessableEntity,
+	entities.ErrorCodeDeviceAmbiguous:  http.StatusUnprocessableEntity,
+	entities.ErrorCodeUnauthenticated:  http.StatusUnauthorized,
+	entities.ErrorCodePermissionDenied: http.StatusForbidden,
+	entities.ErrorCodeUnavailable:      http.StatusServiceUnavailable,
+	entities.ErrorCodeInternal:         http.StatusInternalServerError,
+}
+
+// errorCodeKey - ключ контекста запроса с кодом ошибки ответа для журнала аудита
+const errorCodeKey = "mtconnect.errorCode"
+
+// respondError отправляет ошибку в едином формате {"Status": "error", "Code": ..., "Message": ...};
+// при неоднозначном выборе устройства добавляется список кандидатов "Candidates"
+func respondError(c *gin.Context, err error) {
+	code := entities.ErrorCodeOf(err)
+	status, ok := errorStatuses[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	c.Set(errorCodeKey, string(code))
+	body := gin.H{"Status": "error", "Code": code, "Message": err.Error()}
+	var ambiguity *entities.DeviceAmbiguityError
+	if errors.As(err, &ambiguity) {
+		body["Candidates"] = ambiguity.Candidates
+	}
+	c.JSON(status, body)
+}
+
+// respondBadRequest отправляет ошибку разбора или проверки параметров запроса
+func respondBadRequest(c *gin.Context, err error) {
+	respondError(c, entities.NewError(entities.ErrorCodeInvalidArgument, "некорректный запрос: %w", err))
+}
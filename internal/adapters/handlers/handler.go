@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"MTConnect/api/openapi"
 	"MTConnect/internal/domain/entities"
 	"MTConnect/internal/interfaces"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -23,13 +26,13 @@ func NewHandler(usecase interfaces.Usecases) *Handler {
 func (h *Handler) CreateConnection(c *gin.Context) {
 	var req entities.ConnectionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"Status": "error", "Message": err.Error()})
+		respondBadRequest(c, err)
 		return
 	}
 
 	connInfo, err := h.usecase.CreateConnection(req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"Status": "error", "Message": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -45,15 +48,15 @@ func (h *Handler) GetConnections(c *gin.Context) {
 	})
 }
 
-func (h *Handler) DeleteConnection(c *gin.Context) {
+func (h *Handler) DeleteConnectionByBody(c *gin.Context) {
 	var req entities.SessionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"Status": "error", "Message": err.Error()})
+		respondBadRequest(c, err)
 		return
 	}
 
 	if err := h.usecase.DeleteConnection(req.SessionID); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"Status": "error", "Message": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -64,46 +67,374 @@ func (h *Handler) DeleteConnection(c *gin.Context) {
 	})
 }
 
-func (h *Handler) CheckConnection(c *gin.Context) {
+func (h *Handler) CheckConnectionByBody(c *gin.Context) {
 	var req entities.SessionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"Status": "error", "Message": err.Error()})
+		respondBadRequest(c, err)
 		return
 	}
 
 	connInfo, err := h.usecase.CheckConnection(req.SessionID)
 	if err != nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"Status": "unhealthy", "Message": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"Status": "healthy", "connectionInfo": connInfo})
 }
 
+// --- V1 API Ресурса Подключений ---
+
+func (h *Handler) ListConnections(c *gin.Context) {
+	query, err := connectionQuery(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, err := h.usecase.ListConnections(query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"Status":      "ok",
+		"Total":       page.Total,
+		"Limit":       page.Limit,
+		"Offset":      page.Offset,
+		"Connections": page.Items,
+	})
+}
+
+func (h *Handler) PostConnection(c *gin.Context) {
+	var req entities.ConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err)
+		return
+	}
+
+	connInfo, err := h.usecase.CreateConnection(req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Location", "/api/v1/connections/"+connInfo.SessionID)
+	c.JSON(http.StatusCreated, gin.H{"Status": "ok", "Connection": connInfo})
+}
+
+func (h *Handler) GetConnection(c *gin.Context) {
+	connInfo, err := h.usecase.GetConnection(c.Param("sessionId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"Status": "ok", "Connection": connInfo})
+}
+
+func (h *Handler) PatchConnection(c *gin.Context) {
+	var patch entities.ConnectionPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		respondBadRequest(c, err)
+		return
+	}
+
+	connInfo, err := h.usecase.UpdateConnection(c.Param("sessionId"), patch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"Status": "ok", "Connection": connInfo})
+}
+
+func (h *Handler) DeleteConnection(c *gin.Context) {
+	if err := h.usecase.DeleteConnection(c.Param("sessionId")); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) CheckConnection(c *gin.Context) {
+	connInfo, err := h.usecase.CheckConnection(c.Param("sessionId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"Status": "healthy", "Connection": connInfo})
+}
+
+func (h *Handler) GetActiveAlarms(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+	alarms, err := h.usecase.GetActiveAlarms(sessionID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"Status":    "ok",
+		"SessionID": sessionID,
+		"Count":     len(alarms),
+		"Alarms":    alarms,
+	})
+}
+
+func (h *Handler) GetCurrentData(c *gin.Context) {
+	data, err := h.usecase.GetCurrentData(c.Param("sessionId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
+}
+
+// --- V1 API Обнаружения Устройств ---
+
+func (h *Handler) DiscoverDevices(c *gin.Context) {
+	endpointURL := c.Query("endpoint")
+	devices, err := h.usecase.DiscoverDevices(endpointURL)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"Status":      "ok",
+		"EndpointURL": endpointURL,
+		"Count":       len(devices),
+		"Devices":     devices,
+	})
+}
+
+func (h *Handler) ConnectDiscoveredDevices(c *gin.Context) {
+	var req entities.DiscoverConnectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err)
+		return
+	}
+
+	results, err := h.usecase.ConnectDiscoveredDevices(req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondBulkResults(c, results)
+}
+
 // --- V1 API Управления Опросом ---
 
 func (h *Handler) StartPolling(c *gin.Context) {
 	intervalStr := c.DefaultQuery("interval", "1000") // Интервал по умолчанию 1000мс
 	interval, err := strconv.Atoi(intervalStr)
+	if err != nil || interval <= 0 {
+		respondError(c, entities.NewError(entities.ErrorCodeInvalidArgument, "неверный параметр 'interval', ожидается положительное целое число (миллисекунды)"))
+		return
+	}
+	h.startPolling(c, interval)
+}
+
+func (h *Handler) StopPolling(c *gin.Context) {
+	if err := h.usecase.StopPolling(); err != nil {
+		respondError(c, err)
+		return
+	}
+	// ИЗМЕНЕНИЕ: "status" -> "Status"
+	c.JSON(http.StatusOK, gin.H{"Status": "monitoring stopped"})
+}
+
+// RunCustomMethod обрабатывает пользовательские методы вида /api/v1/{ресурс}:{метод}:
+// POST polling:start, POST polling:stop, POST connections:bulk и GET connections:export
+func (h *Handler) RunCustomMethod(c *gin.Context) {
+	switch c.Request.Method + " " + c.Param("customMethod") {
+	case "POST polling:start":
+		var req entities.PollingRequest
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			respondBadRequest(c, err)
+			return
+		}
+		if req.IntervalMs < 0 {
+			respondError(c, entities.NewError(entities.ErrorCodeInvalidArgument, "IntervalMs должен быть положительным числом миллисекунд"))
+			return
+		}
+		if req.IntervalMs == 0 {
+			req.IntervalMs = 1000
+		}
+		h.startPolling(c, req.IntervalMs)
+	case "POST polling:stop":
+		h.StopPolling(c)
+	case "POST connections:bulk":
+		h.bulkCreateConnections(c)
+	case "GET connections:export":
+		h.exportConnections(c)
+	default:
+		respondError(c, entities.NewError(entities.ErrorCodeNotFound, "метод '%s %s' не поддерживается", c.Request.Method, c.Param("customMethod")))
+	}
+}
+
+func (h *Handler) bulkCreateConnections(c *gin.Context) {
+	var req entities.BulkConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, err)
+		return
+	}
+
+	results, err := h.usecase.BulkCreateConnections(req)
 	if err != nil {
-		// ИЗМЕНЕНИЕ: Приведено к единому формату "Status", "Message"
-		c.JSON(http.StatusBadRequest, gin.H{"Status": "error", "Message": "неверный параметр 'interval', ожидается целое число (миллисекунды)"})
+		respondError(c, err)
+		return
+	}
+	respondBulkResults(c, results)
+}
+
+func (h *Handler) exportConnections(c *gin.Context) {
+	query, err := connectionQuery(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	export, err := h.usecase.ExportConnections(query)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
-	duration := time.Duration(interval) * time.Millisecond
+	c.JSON(http.StatusOK, export)
+}
+
+func (h *Handler) startPolling(c *gin.Context, intervalMs int) {
+	duration := time.Duration(intervalMs) * time.Millisecond
 
 	if err := h.usecase.StartPolling(duration); err != nil {
 		// ИЗМЕНЕНИЕ: Приведено к единому формату "Status", "Message"
-		c.JSON(http.StatusInternalServerError, gin.H{"Status": "error", "Message": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	// ИЗМЕНЕНИЕ: "status" -> "Status"
-	c.JSON(http.StatusOK, gin.H{"Status": "monitoring started"})
+	c.JSON(http.StatusOK, gin.H{"Status": "monitoring started", "IntervalMs": intervalMs})
 }
 
-func (h *Handler) StopPolling(c *gin.Context) {
-	_ = h.usecase.StopPolling()
-	// ИЗМЕНЕНИЕ: "status" -> "Status"
-	c.JSON(http.StatusOK, gin.H{"Status": "monitoring stopped"})
+// --- V1 API Мониторинга ---
+
+func (h *Handler) GetOutboxStats(c *gin.Context) {
+	stats := h.usecase.GetOutboxStats()
+	depth := 0
+	for _, outbox := range stats {
+		depth += outbox.Depth
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"Status":     "ok",
+		"TotalDepth": depth,
+		"Outboxes":   stats,
+	})
+}
+
+func (h *Handler) GetSinkStats(c *gin.Context) {
+	stats := h.usecase.GetSinkStats()
+	c.JSON(http.StatusOK, gin.H{
+		"Status": "ok",
+		"Count":  len(stats),
+		"Sinks":  stats,
+	})
+}
+
+// --- Проверки работоспособности ---
+
+// GetLiveness сообщает, что процесс запущен и обрабатывает запросы; зависимости не проверяются
+func (h *Handler) GetLiveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"Status": entities.HealthStatusOK})
+}
+
+// GetReadiness возвращает состояние компонентов; при состоянии fail отвечает 503,
+// чтобы оркестратор не направлял запросы в неготовый экземпляр
+func (h *Handler) GetReadiness(c *gin.Context) {
+	report := h.usecase.GetReadiness(c.Request.Context())
+	status := http.StatusOK
+	if report.Status == entities.HealthStatusFail {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// --- Журнал ---
+
+// GetLogLevels возвращает уровень журнала по умолчанию и уровни компонентов
+func (h *Handler) GetLogLevels(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"Status": "ok", "Logging": h.usecase.GetLogLevels()})
+}
+
+// UpdateLogLevels изменяет уровни журнала до перезапуска сервиса
+func (h *Handler) UpdateLogLevels(c *gin.Context) {
+	var update entities.LogLevelsUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		respondBadRequest(c, err)
+		return
+	}
+
+	levels, err := h.usecase.SetLogLevels(update)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"Status": "ok", "Logging": levels})
+}
+
+// --- Спецификация API ---
+
+func (h *Handler) GetOpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/yaml", openapi.Spec)
+}
+
+// respondBulkResults отвечает результатами создания пакета подключений
+func respondBulkResults(c *gin.Context, results []entities.BulkConnectionResult) {
+	created := 0
+	for _, result := range results {
+		if result.Connection != nil {
+			created++
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"Status":  "ok",
+		"Created": created,
+		"Failed":  len(results) - created,
+		"Results": results,
+	})
+}
+
+// connectionQuery разбирает параметры фильтрации, сортировки и страницы списка подключений
+func connectionQuery(c *gin.Context) (entities.ConnectionQuery, error) {
+	query := entities.ConnectionQuery{
+		MachineID:    c.Query("machine_id"),
+		Manufacturer: c.Query("manufacturer"),
+		Model:        c.Query("model"),
+		Sort:         c.Query("sort"),
+	}
+	if value, ok := c.GetQuery("healthy"); ok {
+		healthy, err := strconv.ParseBool(value)
+		if err != nil {
+			return query, entities.NewError(entities.ErrorCodeInvalidArgument, "неверный параметр 'healthy', ожидается true или false")
+		}
+		query.Healthy = &healthy
+	}
+	var err error
+	if query.Limit, err = intQuery(c, "limit"); err != nil {
+		return query, err
+	}
+	if query.Offset, err = intQuery(c, "offset"); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// intQuery читает необязательный целочисленный параметр запроса; отсутствующий параметр равен 0
+func intQuery(c *gin.Context, name string) (int, error) {
+	value, ok := c.GetQuery(name)
+	if !ok {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, entities.NewError(entities.ErrorCodeInvalidArgument, "неверный параметр '%s', ожидается целое число", name)
+	}
+	return n, nil
 }
@@ -1,28 +1,221 @@
 package handlers
 
 import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// httpLog - журнал запросов HTTP API
+var httpLog = logging.Logger(logging.ComponentHTTP)
+
+// tracer возвращает трассировщик спанов обработки HTTP-запросов из текущего глобального провайдера
+func tracer() trace.Tracer {
+	return otel.Tracer("MTConnect/internal/adapters/handlers")
+}
+
+// customMethodRoles - роли пользовательских методов по ключу "МЕТОД имя"
+var customMethodRoles = map[string]entities.Role{
+	"POST polling:start":     entities.RoleOperator,
+	"POST polling:stop":      entities.RoleOperator,
+	"POST connections:bulk":  entities.RoleAdmin,
+	"GET connections:export": entities.RoleViewer,
+}
+
 // ProvideRouter настраивает и возвращает HTTP-роутер
-func ProvideRouter(h *Handler) http.Handler {
-	router := gin.Default()
+func ProvideRouter(h *Handler, sh *StreamHandler, auth *AuthMiddleware, cfg *config.AppConfig, metrics interfaces.Metrics) http.Handler {
+	// Отладочные сообщения gin (регистрация маршрутов, предупреждение о режиме debug) пишутся в журнал
+	// с уровнем debug, чтобы вывод сервиса оставался в едином формате
+	gin.DebugPrintFunc = func(format string, values ...interface{}) {
+		httpLog.Debug(strings.TrimSpace(fmt.Sprintf(format, values...)))
+	}
+	gin.DebugPrintRouteFunc = func(method, path, handler string, _ int) {
+		httpLog.Debug("маршрут зарегистрирован", "method", method, "route", path, "handler", handler)
+	}
+	router := gin.New()
+	router.Use(recoverRequests(), traceRequests(), logRequests(), observeRequests(metrics))
+
+	viewer := auth.Require(entities.RoleViewer)
+	operator := auth.Require(entities.RoleOperator)
+	admin := auth.Require(entities.RoleAdmin)
+
+	// Проверки живучести и готовности для оркестратора доступны без аутентификации
+	router.GET("/healthz", h.GetLiveness)
+	router.GET("/readyz", h.GetReadiness)
+
+	// Показатели Prometheus; по умолчанию доступны без аутентификации, как принято для сборщиков
+	if cfg.Metrics.IsEnabled() {
+		chain := []gin.HandlerFunc{gin.WrapH(metrics.Handler())}
+		if cfg.Metrics.RequireAuth {
+			chain = append([]gin.HandlerFunc{viewer}, chain...)
+		}
+		router.GET(cfg.Metrics.Path, chain...)
+	}
 
 	// Новая группа API v1
 	v1 := router.Group("/api/v1")
 	{
-		// Управление подключениями
-		v1.POST("/connect", h.CreateConnection)
-		v1.GET("/connect", h.GetConnections)
-		v1.DELETE("/connect", h.DeleteConnection)
-		v1.POST("/connect/check", h.CheckConnection)
+		// Ресурс подключений
+		v1.GET("/connections", viewer, h.ListConnections)
+		v1.POST("/connections", admin, h.PostConnection)
+		v1.GET("/connections/:sessionId", viewer, h.GetConnection)
+		v1.PATCH("/connections/:sessionId", admin, h.PatchConnection)
+		v1.DELETE("/connections/:sessionId", admin, h.DeleteConnection)
+		v1.POST("/connections/:sessionId/check", viewer, h.CheckConnection)
+		v1.GET("/connections/:sessionId/alarms", viewer, h.GetActiveAlarms)
+		v1.GET("/connections/:sessionId/current", viewer, h.GetCurrentData)
+
+		// Обнаружение устройств агента; обращается к произвольному адресу, поэтому требует роль admin
+		v1.GET("/discover", admin, h.DiscoverDevices)
+		v1.POST("/discover/connect", admin, h.ConnectDiscoveredDevices)
+
+		// Пользовательские методы вида {ресурс}:{метод}. Дерево маршрутов gin не позволяет
+		// зарегистрировать двоеточие в статическом пути, поэтому метод разбирается обработчиком.
+		customMethod := auth.RequireCustomMethod(customMethodRoles)
+		v1.POST("/:customMethod", customMethod, h.RunCustomMethod)
+		v1.GET("/:customMethod", customMethod, h.RunCustomMethod)
+
+		// Устаревшие маршруты, сохранены для совместимости
+		v1.POST("/connect", deprecated("/api/v1/connections"), admin, h.CreateConnection)
+		v1.GET("/connect", deprecated("/api/v1/connections"), viewer, h.GetConnections)
+		v1.DELETE("/connect", deprecated("/api/v1/connections/{sessionId}"), admin, h.DeleteConnectionByBody)
+		v1.POST("/connect/check", deprecated("/api/v1/connections/{sessionId}/check"), viewer, h.CheckConnectionByBody)
+		v1.GET("/connect/:sessionId/alarms", deprecated("/api/v1/connections/{sessionId}/alarms"), viewer, h.GetActiveAlarms)
+		v1.GET("/connect/:sessionId/current", deprecated("/api/v1/connections/{sessionId}/current"), viewer, h.GetCurrentData)
+		v1.GET("/polling/start", deprecated("/api/v1/polling:start"), operator, h.StartPolling)
+		v1.GET("/polling/stop", deprecated("/api/v1/polling:stop"), operator, h.StopPolling)
+
+		// Мониторинг
+		v1.GET("/outbox", viewer, h.GetOutboxStats)
+		v1.GET("/sinks", viewer, h.GetSinkStats)
 
-		// Управление опросом
-		v1.GET("/polling/start", h.StartPolling)
-		v1.GET("/polling/stop", h.StopPolling)
+		// Уровни журнала; изменения действуют до перезапуска сервиса
+		v1.GET("/logging/levels", viewer, h.GetLogLevels)
+		v1.PUT("/logging/levels", admin, h.UpdateLogLevels)
+		v1.PATCH("/logging/levels", admin, h.UpdateLogLevels)
+
+		// Потоки реального времени
+		v1.GET("/stream", auth.RequireStream(entities.RoleViewer), sh.StreamSSE)
+		v1.GET("/ws", auth.RequireStream(entities.RoleViewer), sh.StreamWebSocket)
+
+		// Спецификация OpenAPI доступна без аутентификации
+		v1.GET("/openapi.yaml", h.GetOpenAPISpec)
 	}
 
 	return router
 }
+
+// observeRequests учитывает запросы в показателях HTTP
+func observeRequests(metrics interfaces.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+		c.Next()
+		metrics.ObserveHTTPRequest(c.Request.Method, routeName(c), c.Writer.Status(), time.Since(started))
+	}
+}
+
+// recoverRequests отвечает 500 на панику обработчика и записывает ее в журнал вместо стандартного вывода gin
+func recoverRequests() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		httpLog.ErrorContext(c.Request.Context(), "паника при обработке запроса",
+			"method", c.Request.Method, "route", routeName(c), logging.Err(fmt.Errorf("%v", recovered)))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
+
+// logRequests записывает запросы в журнал: ошибки сервера - с уровнем error, ошибки клиента - warn,
+// остальные - debug, чтобы частые опросы API не заполняли журнал
+func logRequests() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelDebug
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		httpLog.Log(c.Request.Context(), level, "запрос обработан",
+			"method", c.Request.Method,
+			"route", routeName(c),
+			"status", status,
+			"durationMs", time.Since(started).Milliseconds(),
+			"clientIp", c.ClientIP(),
+		)
+	}
+}
+
+// traceRequests записывает обработку запроса спаном "МЕТОД маршрут", продолжая трассу
+// из заголовка traceparent, если клиент его передал
+func traceRequests() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := routeName(c)
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracer().Start(ctx, c.Request.Method+" "+route, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(c.Request.Method),
+			semconv.HTTPRoute(route),
+			semconv.URLPath(c.Request.URL.Path),
+		))
+		defer span.End()
+		if sessionID := c.Param("sessionId"); sessionID != "" {
+			span.SetAttributes(attribute.String("mtconnect.session_id", sessionID))
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// routeName возвращает шаблон маршрута gin, чтобы идентификаторы сессий не размножали ряды показателей
+// и имена спанов. Пользовательские методы различаются по имени, а запросы к незарегистрированным
+// путям объединяются в "unmatched".
+func routeName(c *gin.Context) string {
+	route := c.FullPath()
+	if route == "" {
+		return "unmatched"
+	}
+	if method := c.Param("customMethod"); method != "" {
+		if _, known := customMethodRoles[c.Request.Method+" "+method]; known {
+			route = strings.Replace(route, ":customMethod", method, 1)
+		}
+	}
+	return route
+}
+
+// deprecated помечает ответ устаревшего маршрута заголовками Deprecation и Link на замену.
+// {sessionId} в адресе замены подставляется из пути запроса, если он там есть.
+func deprecated(successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		link := successor
+		if sessionID := c.Param("sessionId"); sessionID != "" {
+			link = strings.ReplaceAll(link, "{sessionId}", sessionID)
+		}
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+link+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// sseRetryMs - задержка переподключения, которую браузер использует после обрыва потока
+	sseRetryMs = 3000
+	// wsWriteTimeout - время на отправку одного сообщения WebSocket; медленный клиент отключается
+	wsWriteTimeout = 10 * time.Second
+)
+
+// StreamHandler обслуживает потоки реального времени: SSE и WebSocket
+type StreamHandler struct {
+	usecase  interfaces.Usecases
+	cfg      config.StreamConfig
+	upgrader websocket.Upgrader
+}
+
+func NewStreamHandler(usecase interfaces.Usecases, cfg *config.AppConfig) *StreamHandler {
+	h := &StreamHandler{usecase: usecase, cfg: cfg.Stream}
+	h.upgrader = websocket.Upgrader{CheckOrigin: h.checkOrigin}
+	return h
+}
+
+// StreamSSE передает события в формате text/event-stream
+func (h *StreamHandler) StreamSSE(c *gin.Context) {
+	sub, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	// Поток живет дольше ReadTimeout и WriteTimeout сервера
+	controller := http.NewResponseController(c.Writer)
+	_ = controller.SetReadDeadline(time.Time{})
+	_ = controller.SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetryMs)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(time.Duration(h.cfg.HeartbeatSec) * time.Second)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		case event, open := <-sub.Events():
+			if !open {
+				data, _ := json.Marshal(gin.H{"Status": "error", "Message": errorMessage(sub.Err())})
+				fmt.Fprintf(c.Writer, "event: error\ndata: %s\n\n", data)
+				c.Writer.Flush()
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				httpLog.Error("не удалось сериализовать событие", "eventId", event.ID, logging.Err(err))
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, data)
+		}
+		c.Writer.Flush()
+	}
+}
+
+// StreamWebSocket передает события JSON-сообщениями WebSocket
+func (h *StreamHandler) StreamWebSocket(c *gin.Context) {
+	sub, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrader уже отправил клиенту ответ с ошибкой
+		return
+	}
+	defer conn.Close()
+
+	heartbeatInterval := time.Duration(h.cfg.HeartbeatSec) * time.Second
+	conn.SetReadLimit(4096)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * heartbeatInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * heartbeatInterval))
+	})
+	// Входящие сообщения клиента не используются; чтение нужно для обработки pong и закрытия соединения
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case event, open := <-sub.Events():
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if !open {
+				message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, errorMessage(sub.Err()))
+				_ = conn.WriteMessage(websocket.CloseMessage, message)
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// subscribe создает подписку по параметрам запроса: sessions, events, fields и Last-Event-ID
+// (заголовок или параметр last_event_id, так как браузерный WebSocket не передает заголовки)
+func (h *StreamHandler) subscribe(c *gin.Context) (interfaces.StreamSubscription, bool) {
+	filter := entities.StreamFilter{
+		SessionIDs: queryList(c, "sessions"),
+		Events:     queryList(c, "events"),
+		Fields:     queryList(c, "fields"),
+	}
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var from uint64
+	if lastEventID != "" {
+		var err error
+		if from, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			respondError(c, entities.NewError(entities.ErrorCodeInvalidArgument, "некорректный Last-Event-ID: %s", lastEventID))
+			return nil, false
+		}
+	}
+
+	sub, err := h.usecase.Subscribe(filter, from)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	return sub, true
+}
+
+func (h *StreamHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range h.cfg.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// queryList собирает значения параметра, переданные через запятую или повтором параметра
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, item := range c.QueryArray(name) {
+		for _, value := range strings.Split(item, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return "поток закрыт"
+	}
+	return err.Error()
+}
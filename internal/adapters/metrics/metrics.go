@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"MTConnect/internal/interfaces"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace - общий префикс имен показателей
+const namespace = "mtconnect"
+
+// Metrics хранит показатели сервиса в собственном реестре Prometheus
+type Metrics struct {
+	registry *prometheus.Registry
+
+	pollDuration    *prometheus.HistogramVec
+	fetchErrors     *prometheus.CounterVec
+	parseDuration   *prometheus.HistogramVec
+	dataItems       *prometheus.CounterVec
+	produceDuration *prometheus.HistogramVec
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+}
+
+// NewMetrics создает реестр с показателями сервиса, среды выполнения Go и процесса
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		pollDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "poll_duration_seconds",
+			Help:      "Длительность цикла опроса сессии: запрос /current, разбор и публикация",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"session_id", "machine_id"}),
+		fetchErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "fetch_errors_total",
+			Help:      "Ошибки получения и разбора ответов агента по классам",
+		}, []string{"session_id", "kind"}),
+		parseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "parse_duration_seconds",
+			Help:      "Длительность разбора ответа агента по этапам: unmarshal - XML, map - сопоставление в MachineData",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, []string{"stage"}),
+		dataItems: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "data_items_total",
+			Help:      "Элементы данных ответов агента: mapped - сопоставлены, dropped - отброшены из-за отсутствия метаданных /probe",
+		}, []string{"machine_id", "result"}),
+		produceDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sink_produce_duration_seconds",
+			Help:      "Длительность отправки сообщения синком по результату",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"sink", "type", "result"}),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Запросы REST API",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Длительность обработки запросов REST API; для потоков SSE и WebSocket - длительность подписки",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.pollDuration,
+		m.fetchErrors,
+		m.parseDuration,
+		m.dataItems,
+		m.produceDuration,
+		m.httpRequests,
+		m.httpDuration,
+	)
+	return m
+}
+
+// NewRecorder предоставляет показатели как интерфейс для сервисов и обработчиков
+func NewRecorder(m *Metrics) interfaces.Metrics {
+	return m
+}
+
+func (m *Metrics) ObservePoll(sessionID, machineID string, duration time.Duration) {
+	m.pollDuration.WithLabelValues(sessionID, machineID).Observe(duration.Seconds())
+}
+
+func (m *Metrics) FetchError(sessionID, kind string) {
+	m.fetchErrors.WithLabelValues(sessionID, kind).Inc()
+}
+
+func (m *Metrics) ObserveParse(stage string, duration time.Duration) {
+	m.parseDuration.WithLabelValues(stage).Observe(duration.Seconds())
+}
+
+func (m *Metrics) DataItems(machineID string, mapped, dropped int) {
+	m.dataItems.WithLabelValues(machineID, "mapped").Add(float64(mapped))
+	m.dataItems.WithLabelValues(machineID, "dropped").Add(float64(dropped))
+}
+
+func (m *Metrics) ObserveProduce(sink, sinkType string, duration time.Duration, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.produceDuration.WithLabelValues(sink, sinkType, result).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ForgetSession удаляет ряды сессии, чтобы удаленные и перенастроенные подключения не копились в выдаче
+func (m *Metrics) ForgetSession(sessionID string) {
+	m.pollDuration.DeletePartialMatch(prometheus.Labels{"session_id": sessionID})
+	m.fetchErrors.DeletePartialMatch(prometheus.Labels{"session_id": sessionID})
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Register добавляет в реестр дополнительный сборщик
+func (m *Metrics) Register(collector prometheus.Collector) error {
+	return m.registry.Register(collector)
+}
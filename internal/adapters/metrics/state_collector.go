@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"MTConnect/internal/interfaces"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StateCollector снимает показатели состояния в момент запроса Prometheus: размер пула подключений,
+// их исправность, число активных опросов, очереди синков и дисковые очереди. Значения берутся
+// из сервисов напрямую, поэтому не требуют отдельного учета при каждом изменении.
+type StateCollector struct {
+	connections interfaces.ConnectionService
+	polling     interfaces.PollingService
+	sinks       interfaces.SinkMonitor
+	outboxes    interfaces.OutboxMonitor
+
+	poolSize      *prometheus.Desc
+	healthy       *prometheus.Desc
+	activePolls   *prometheus.Desc
+	sinkQueued    *prometheus.Desc
+	sinkCapacity  *prometheus.Desc
+	sinkMessages  *prometheus.Desc
+	outboxDepth   *prometheus.Desc
+	outboxBytes   *prometheus.Desc
+	outboxAge     *prometheus.Desc
+	outboxDropped *prometheus.Desc
+}
+
+func NewStateCollector(connections interfaces.ConnectionService, polling interfaces.PollingService, sinks interfaces.SinkMonitor, outboxes interfaces.OutboxMonitor) *StateCollector {
+	return &StateCollector{
+		connections: connections,
+		polling:     polling,
+		sinks:       sinks,
+		outboxes:    outboxes,
+		poolSize: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "connections"),
+			"Число подключений в пуле", nil, nil),
+		healthy: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "connection_healthy"),
+			"Исправность подключения (IsHealthy): 1 - исправно, 0 - нет", []string{"session_id", "machine_id", "endpoint"}, nil),
+		activePolls: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "active_polls"),
+			"Число сессий с запущенным опросом", nil, nil),
+		sinkQueued: prometheus.NewDesc(prometheus.BuildFQName(namespace, "sink", "queue_length"),
+			"Сообщения в очереди синка", []string{"sink", "type"}, nil),
+		sinkCapacity: prometheus.NewDesc(prometheus.BuildFQName(namespace, "sink", "queue_capacity"),
+			"Емкость очереди синка", []string{"sink", "type"}, nil),
+		sinkMessages: prometheus.NewDesc(prometheus.BuildFQName(namespace, "sink", "messages_total"),
+			"Сообщения синка по результату: delivered, failed, dropped (очередь переполнена)", []string{"sink", "type", "result"}, nil),
+		outboxDepth: prometheus.NewDesc(prometheus.BuildFQName(namespace, "outbox", "depth"),
+			"Сообщения в дисковой очереди", []string{"outbox"}, nil),
+		outboxBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, "outbox", "bytes"),
+			"Размер дисковой очереди в байтах", []string{"outbox"}, nil),
+		outboxAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, "outbox", "oldest_age_seconds"),
+			"Возраст самого старого сообщения дисковой очереди", []string{"outbox"}, nil),
+		outboxDropped: prometheus.NewDesc(prometheus.BuildFQName(namespace, "outbox", "dropped_total"),
+			"Сообщения, вытесненные из дисковой очереди при превышении размера", []string{"outbox"}, nil),
+	}
+}
+
+// RegisterStateCollector добавляет сборщик состояния в реестр показателей
+func RegisterStateCollector(m *Metrics, collector *StateCollector) error {
+	return m.Register(collector)
+}
+
+func (c *StateCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		c.poolSize, c.healthy, c.activePolls,
+		c.sinkQueued, c.sinkCapacity, c.sinkMessages,
+		c.outboxDepth, c.outboxBytes, c.outboxAge, c.outboxDropped,
+	} {
+		ch <- desc
+	}
+}
+
+func (c *StateCollector) Collect(ch chan<- prometheus.Metric) {
+	connections := c.connections.GetAllConnections()
+	ch <- prometheus.MustNewConstMetric(c.poolSize, prometheus.GaugeValue, float64(len(connections)))
+	for _, conn := range connections {
+		healthy := 0.0
+		if conn.IsHealthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.healthy, prometheus.GaugeValue, healthy, conn.SessionID, conn.MachineID, conn.Config.EndpointURL)
+	}
+	ch <- prometheus.MustNewConstMetric(c.activePolls, prometheus.GaugeValue, float64(c.polling.ActivePolls()))
+
+	for _, sink := range c.sinks.SinkStats() {
+		ch <- prometheus.MustNewConstMetric(c.sinkQueued, prometheus.GaugeValue, float64(sink.Queued), sink.Name, sink.Type)
+		ch <- prometheus.MustNewConstMetric(c.sinkCapacity, prometheus.GaugeValue, float64(sink.QueueCapacity), sink.Name, sink.Type)
+		ch <- prometheus.MustNewConstMetric(c.sinkMessages, prometheus.CounterValue, float64(sink.Delivered), sink.Name, sink.Type, "delivered")
+		ch <- prometheus.MustNewConstMetric(c.sinkMessages, prometheus.CounterValue, float64(sink.Failed), sink.Name, sink.Type, "failed")
+		ch <- prometheus.MustNewConstMetric(c.sinkMessages, prometheus.CounterValue, float64(sink.Dropped), sink.Name, sink.Type, "dropped")
+	}
+	for _, outbox := range c.outboxes.OutboxStats() {
+		ch <- prometheus.MustNewConstMetric(c.outboxDepth, prometheus.GaugeValue, float64(outbox.Depth), outbox.Name)
+		ch <- prometheus.MustNewConstMetric(c.outboxBytes, prometheus.GaugeValue, float64(outbox.Bytes), outbox.Name)
+		ch <- prometheus.MustNewConstMetric(c.outboxAge, prometheus.GaugeValue, outbox.OldestAgeSec, outbox.Name)
+		ch <- prometheus.MustNewConstMetric(c.outboxDropped, prometheus.CounterValue, float64(outbox.Dropped), outbox.Name)
+	}
+}
@@ -0,0 +1,64 @@
+package producers
+
+import (
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// avroNative переводит запись Protobuf в представление goavro по именам полей.
+// Схемы *.avsc повторяют mtconnect.proto: optional-поля и вложенные записи - объединения с null.
+func avroNative(message protoreflect.Message) map[string]interface{} {
+	fields := message.Descriptor().Fields()
+	native := make(map[string]interface{}, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		value := message.Get(fd)
+		name := string(fd.Name())
+		switch {
+		case fd.IsMap():
+			items := make(map[string]interface{}, value.Map().Len())
+			value.Map().Range(func(key protoreflect.MapKey, item protoreflect.Value) bool {
+				items[key.String()] = avroValue(fd.MapValue(), item)
+				return true
+			})
+			native[name] = items
+		case fd.IsList():
+			list := value.List()
+			items := make([]interface{}, 0, list.Len())
+			for j := 0; j < list.Len(); j++ {
+				items = append(items, avroValue(fd, list.Get(j)))
+			}
+			native[name] = items
+		case fd.HasPresence():
+			if !message.Has(fd) {
+				native[name] = nil
+				continue
+			}
+			native[name] = goavro.Union(avroTypeName(fd), avroValue(fd, value))
+		default:
+			native[name] = avroValue(fd, value)
+		}
+	}
+	return native
+}
+
+func avroValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) interface{} {
+	if fd.Kind() == protoreflect.MessageKind {
+		return avroNative(value.Message())
+	}
+	return value.Interface()
+}
+
+func avroTypeName(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return string(fd.Message().FullName())
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.Int64Kind:
+		return "long"
+	case protoreflect.DoubleKind:
+		return "double"
+	}
+	return "string"
+}
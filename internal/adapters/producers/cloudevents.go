@@ -0,0 +1,158 @@
+package producers
+
+import (
+	"MTConnect/internal/buildinfo"
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsTypePrefix  = "mtconnect.machine."
+
+	contentTypeCloudEvents      = "application/cloudevents+json"
+	contentTypeCloudEventsBatch = "application/cloudevents-batch+json"
+)
+
+// cloudEventsHeaderPrefixes - префиксы заголовков binary-режима согласно привязкам протоколов CloudEvents.
+// MQTT 5 передает атрибуты в user properties без префикса.
+var cloudEventsHeaderPrefixes = map[string]string{
+	config.SinkTypeKafka:   "ce_",
+	config.SinkTypeWebhook: "ce-",
+	config.SinkTypeMQTT:    "",
+}
+
+// cloudEvent - конверт CloudEvents 1.0 в structured-режиме (JSON).
+// Номера последовательностей передаются строками: тип Integer в CloudEvents 32-битный.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	AgentInstanceID string          `json:"agentinstanceid,omitempty"`
+	FirstSequence   string          `json:"firstsequence,omitempty"`
+	LastSequence    string          `json:"lastsequence,omitempty"`
+	SessionID       string          `json:"sessionid,omitempty"`
+	ServiceVersion  string          `json:"serviceversion"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      string          `json:"data_base64,omitempty"`
+}
+
+// cloudEventsFormatter добавляет к сообщению атрибуты CloudEvents: в заголовки (binary)
+// или оборачивая тело в JSON-конверт (structured)
+type cloudEventsFormatter struct {
+	mode         string
+	headerPrefix string
+}
+
+// newCloudEventsFormatter возвращает nil, если CloudEvents для синка отключены
+func newCloudEventsFormatter(mode, sinkType string) (*cloudEventsFormatter, error) {
+	switch mode {
+	case config.CloudEventsNone:
+		return nil, nil
+	case config.CloudEventsBinary, config.CloudEventsStructured:
+	default:
+		return nil, fmt.Errorf("неизвестный режим cloudevents: '%s'", mode)
+	}
+	prefix, ok := cloudEventsHeaderPrefixes[sinkType]
+	if !ok {
+		prefix = "ce_"
+	}
+	return &cloudEventsFormatter{mode: mode, headerPrefix: prefix}, nil
+}
+
+// apply дополняет закодированное сообщение атрибутами CloudEvents
+func (f *cloudEventsFormatter) apply(msg *entities.Message) error {
+	event := newCloudEvent(msg)
+	if f.mode == config.CloudEventsBinary {
+		if msg.Headers == nil {
+			msg.Headers = make(map[string]string)
+		}
+		for name, value := range event.attributes() {
+			msg.Headers[f.headerPrefix+name] = value
+		}
+		return nil
+	}
+
+	if isJSONContentType(msg.ContentType) {
+		event.Data = json.RawMessage(msg.Value)
+	} else {
+		event.DataBase64 = base64.StdEncoding.EncodeToString(msg.Value)
+	}
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("ошибка формирования CloudEvent: %w", err)
+	}
+	msg.Value, msg.ContentType = value, contentTypeCloudEvents
+	return nil
+}
+
+func newCloudEvent(msg *entities.Message) cloudEvent {
+	id := msg.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	source := msg.EndpointURL
+	if source == "" {
+		source = "urn:" + buildinfo.ServiceName
+	}
+	event := cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            cloudEventsTypePrefix + msg.Type,
+		Subject:         msg.MachineID,
+		Time:            msg.Timestamp.UTC().Format(time.RFC3339Nano),
+		DataContentType: msg.ContentType,
+		AgentInstanceID: msg.AgentInstanceID,
+		SessionID:       msg.SessionID,
+		ServiceVersion:  buildinfo.Version,
+	}
+	if msg.LastSequence > 0 {
+		event.FirstSequence = strconv.FormatUint(msg.FirstSequence, 10)
+		event.LastSequence = strconv.FormatUint(msg.LastSequence, 10)
+	}
+	return event
+}
+
+// attributes возвращает атрибуты контекста для binary-режима.
+// datacontenttype не включается: он передается штатным заголовком content-type протокола.
+func (e cloudEvent) attributes() map[string]string {
+	attributes := map[string]string{
+		"specversion":    e.SpecVersion,
+		"id":             e.ID,
+		"source":         e.Source,
+		"type":           e.Type,
+		"time":           e.Time,
+		"serviceversion": e.ServiceVersion,
+	}
+	optional := map[string]string{
+		"subject":         e.Subject,
+		"agentinstanceid": e.AgentInstanceID,
+		"firstsequence":   e.FirstSequence,
+		"lastsequence":    e.LastSequence,
+		"sessionid":       e.SessionID,
+	}
+	for name, value := range optional {
+		if value != "" {
+			attributes[name] = value
+		}
+	}
+	return attributes
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return mediaType == contentTypeJSON || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,135 @@
+package producers
+
+import (
+	mtconnectv1 "MTConnect/api/mtconnect/v1"
+	"MTConnect/internal/adapters/schema"
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"encoding/json"
+	"fmt"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeAvro     = "avro/binary"
+)
+
+// Encoder сериализует полезную нагрузку сообщения в формат конкретного синка
+type Encoder interface {
+	Encode(msg *entities.Message) (value []byte, contentType string, err error)
+}
+
+// newEncoder возвращает кодировщик, выбранный в конфигурации синка
+func newEncoder(sink config.SinkConfig) (Encoder, error) {
+	var registry *schemaRegistry
+	if sink.SchemaRegistry != nil {
+		var err error
+		if registry, err = newSchemaRegistry(sink.SchemaRegistry); err != nil {
+			return nil, err
+		}
+	}
+
+	switch sink.Encoding {
+	case "", "json":
+		if registry != nil {
+			return nil, fmt.Errorf("schema registry поддерживается только для форматов protobuf и avro")
+		}
+		return jsonEncoder{}, nil
+	case "protobuf":
+		return &protobufEncoder{registry: registry}, nil
+	case "avro":
+		return newAvroEncoder(registry)
+	}
+	return nil, fmt.Errorf("неизвестный формат сериализации: '%s'", sink.Encoding)
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(msg *entities.Message) ([]byte, string, error) {
+	value, err := json.Marshal(msg.Payload)
+	return value, contentTypeJSON, err
+}
+
+// protobufEncoder сериализует сообщения по схеме mtconnect.proto
+type protobufEncoder struct {
+	registry *schemaRegistry
+}
+
+func (e *protobufEncoder) Encode(msg *entities.Message) ([]byte, string, error) {
+	record, err := schema.ToMessage(msg.Payload)
+	if err != nil {
+		return nil, "", err
+	}
+	value, err := proto.Marshal(record)
+	if err != nil {
+		return nil, "", err
+	}
+	descriptor := record.ProtoReflect().Descriptor()
+	if e.registry == nil {
+		return value, contentTypeProtobuf + "; messageType=" + string(descriptor.FullName()), nil
+	}
+
+	schemaID, err := e.registry.schemaID(string(descriptor.FullName()), schemaTypeProtobuf, mtconnectv1.ProtoSchema)
+	if err != nil {
+		return nil, "", err
+	}
+	return append(confluentHeader(schemaID, descriptor.Index()), value...), contentTypeProtobuf, nil
+}
+
+// avroEncoder сериализует сообщения по схемам *.avsc, эквивалентным mtconnect.proto
+type avroEncoder struct {
+	registry *schemaRegistry
+	codecs   map[string]*goavro.Codec
+	schemas  map[string]string
+}
+
+func newAvroEncoder(registry *schemaRegistry) (*avroEncoder, error) {
+	encoder := &avroEncoder{
+		registry: registry,
+		codecs:   make(map[string]*goavro.Codec),
+		schemas:  make(map[string]string),
+	}
+	for _, record := range []string{"MachineData", "MachineDataDelta", "AlarmEvent", "LifecycleEvent", "Observation"} {
+		schema, err := mtconnectv1.AvroSchema(record)
+		if err != nil {
+			return nil, err
+		}
+		codec, err := goavro.NewCodec(schema)
+		if err != nil {
+			return nil, fmt.Errorf("некорректная схема Avro %s: %w", record, err)
+		}
+		encoder.codecs[record] = codec
+		encoder.schemas[record] = schema
+	}
+	return encoder, nil
+}
+
+func (e *avroEncoder) Encode(msg *entities.Message) ([]byte, string, error) {
+	record, err := schema.ToMessage(msg.Payload)
+	if err != nil {
+		return nil, "", err
+	}
+	descriptor := record.ProtoReflect().Descriptor()
+	name := string(descriptor.Name())
+	codec, ok := e.codecs[name]
+	if !ok {
+		return nil, "", fmt.Errorf("схема Avro для '%s' не найдена", name)
+	}
+	value, err := codec.BinaryFromNative(nil, avroNative(record.ProtoReflect()))
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка сериализации Avro %s: %w", name, err)
+	}
+	if e.registry == nil {
+		return value, contentTypeAvro, nil
+	}
+
+	schemaID, err := e.registry.schemaID(string(descriptor.FullName()), schemaTypeAvro, e.schemas[name])
+	if err != nil {
+		return nil, "", err
+	}
+	return append(confluentHeader(schemaID, -1), value...), contentTypeAvro, nil
+}
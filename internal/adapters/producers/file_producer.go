@@ -0,0 +1,510 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var fileLog = logging.Logger(logging.ComponentFile)
+
+const (
+	fileFormatNDJSON = "ndjson"
+	fileFormatCSV    = "csv"
+
+	fileHousekeepingInterval = time.Minute
+	fileRetentionInterval    = 10 * time.Minute
+	// fileFlushInterval - период сброса буферов gzip, чтобы после сбоя терялись только последние записи
+	fileFlushInterval = 5 * time.Second
+
+	// csvFieldsColumn - последняя колонка CSV с остальными плоскими полями сообщения в виде JSON-объекта
+	csvFieldsColumn = "Fields"
+)
+
+// csvColumnsByType - постоянные колонки CSV для каждого типа сообщений: скалярные поля верхнего уровня.
+// Вложенные и редкие поля попадают в колонку Fields, поэтому заголовок файла не меняется от сообщения к сообщению.
+var csvColumnsByType = map[string][]string{
+	entities.MessageTypeSnapshot: {
+		"MachineId", "Id", "Timestamp", "IsEnabled", "IsInEmergency", "MachineState", "ProgramMode", "TmMode",
+		"HandleRetraceStatus", "AxisMovementStatus", "MstbStatus", "EmergencyStatus", "AlarmStatus", "EditStatus",
+		"ManualMode", "WriteStatus", "LabelSkipStatus", "WarningStatus", "BatteryStatus", "activeToolNumber",
+		"toolOffsetNumber", "hasAlarms",
+	},
+	entities.MessageTypeDelta: {"MachineId", "Id", "Timestamp"},
+	entities.MessageTypeAlarm: {
+		"eventType", "sessionId", "machineId", "dataItemId", "nativeCode", "nativeSeverity", "qualifier", "level",
+		"previousLevel", "type", "componentId", "componentName", "message", "startTime", "endTime", "durationMs", "reason",
+	},
+	entities.MessageTypeLifecycle: {"eventType", "sessionId", "machineId", "manufacturer", "endpointUrl", "timestamp", "message"},
+	entities.MessageTypeObservation: {
+		"machineId", "deviceUuid", "sequence", "timestamp", "dataItemId", "name", "category", "type", "subType",
+		"componentPath", "value", "nativeCode", "nativeSeverity", "qualifier", "message",
+	},
+}
+
+// FileProducer записывает сообщения в локальный архив: отдельный каталог на станок
+// и отдельный файл на тип сообщения. Файлы ротируются по размеру и по часам,
+// могут сжиматься gzip, а устаревшие файлы удаляются согласно политике хранения.
+type FileProducer struct {
+	cfg      config.FileSinkConfig
+	maxBytes int64
+
+	mu           sync.Mutex
+	files        map[string]*archiveFile
+	closed       bool
+	filesCreated uint64
+	filesDeleted uint64
+	bytesWritten uint64
+	lastRotation time.Time
+	lastError    string
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewFileSink создает продюсер локального архива для синка
+func NewFileSink(sink config.SinkConfig, _ *config.AppConfig) (interfaces.DataProducer, error) {
+	if sink.File == nil {
+		return nil, fmt.Errorf("для синка '%s' не задан раздел file", sink.Name)
+	}
+	cfg := *sink.File
+	switch cfg.Format {
+	case fileFormatNDJSON:
+		if sink.Encoding != "" && sink.Encoding != "json" {
+			return nil, fmt.Errorf("формат ndjson синка '%s' требует сериализации json", sink.Name)
+		}
+	case fileFormatCSV:
+	default:
+		return nil, fmt.Errorf("неизвестный формат файлов '%s' в синке '%s'", cfg.Format, sink.Name)
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("не удалось создать каталог архива %s: %w", cfg.Dir, err)
+	}
+
+	producer := &FileProducer{
+		cfg:      cfg,
+		maxBytes: int64(cfg.RotateMaxMB) << 20,
+		files:    make(map[string]*archiveFile),
+		stop:     make(chan struct{}),
+	}
+	producer.applyRetention()
+	producer.wg.Add(1)
+	go producer.housekeepingLoop()
+	return producer, nil
+}
+
+// Produce дописывает сообщение в текущий файл станка, при необходимости открывая новый
+func (p *FileProducer) Produce(_ context.Context, msg *entities.Message) error {
+	var fields map[string]interface{}
+	if p.cfg.Format == fileFormatCSV {
+		fields = csvFields(msg)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return fmt.Errorf("файловый синк уже закрыт")
+	}
+
+	dir := filepath.Join(p.cfg.Dir, filepath.FromSlash(renderTopic(p.cfg.PathTemplate, msg, sanitizePathSegment)))
+	key := dir + "|" + msg.Type
+	now := time.Now().UTC()
+
+	file := p.files[key]
+	if file != nil && p.needsRotation(file, now) {
+		p.closeFile(key, file)
+		file = nil
+	}
+	if file == nil {
+		var err error
+		if file, err = p.openFile(dir, msg.Type, now); err != nil {
+			return p.fail(err)
+		}
+		p.files[key] = file
+	}
+
+	written, err := file.write(msg, fields)
+	p.bytesWritten += uint64(written)
+	if err != nil {
+		p.closeFile(key, file)
+		return p.fail(fmt.Errorf("ошибка записи в %s: %w", file.path, err))
+	}
+	return nil
+}
+
+// Close закрывает все открытые файлы архива
+func (p *FileProducer) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	close(p.stop)
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var errs []error
+	for key, file := range p.files {
+		if err := file.close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(p.files, key)
+	}
+	return errors.Join(errs...)
+}
+
+// SinkDetails возвращает состояние архива
+func (p *FileProducer) SinkDetails() interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := entities.FileSinkStats{
+		Dir:          p.cfg.Dir,
+		Format:       p.cfg.Format,
+		OpenFiles:    len(p.files),
+		FilesCreated: p.filesCreated,
+		FilesDeleted: p.filesDeleted,
+		BytesWritten: p.bytesWritten,
+		LastError:    p.lastError,
+	}
+	if !p.lastRotation.IsZero() {
+		lastRotation := p.lastRotation
+		stats.LastRotation = &lastRotation
+	}
+	return stats
+}
+
+// needsRotation проверяет, нужно ли начать новый файл: сменился час или превышен размер
+func (p *FileProducer) needsRotation(file *archiveFile, now time.Time) bool {
+	if *p.cfg.RotateHourly && !now.Truncate(time.Hour).Equal(file.hour) {
+		return true
+	}
+	return file.counter.n >= p.maxBytes
+}
+
+func (p *FileProducer) openFile(dir, messageType string, now time.Time) (*archiveFile, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("не удалось создать каталог %s: %w", dir, err)
+	}
+
+	ext := "." + p.cfg.Format
+	if p.cfg.Gzip {
+		ext += ".gz"
+	}
+	base := sanitizePathSegment(messageType) + "-" + now.Format("20060102T150405Z")
+	path := filepath.Join(dir, base+ext)
+	for i := 1; ; i++ {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		path = filepath.Join(dir, base+"-"+strconv.Itoa(i)+ext)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать файл %s: %w", path, err)
+	}
+	file := &archiveFile{
+		path:    path,
+		file:    f,
+		counter: &countingWriter{w: f},
+		hour:    now.Truncate(time.Hour),
+	}
+	file.out = file.counter
+	if p.cfg.Gzip {
+		file.gz = gzip.NewWriter(file.counter)
+		file.out = file.gz
+	}
+	if p.cfg.Format == fileFormatCSV {
+		file.columns = csvColumnsByType[messageType]
+		file.csv = csv.NewWriter(file.out)
+		if err := file.csv.Write(append(append([]string(nil), file.columns...), csvFieldsColumn)); err != nil {
+			_ = file.close()
+			return nil, fmt.Errorf("не удалось записать заголовок CSV в %s: %w", path, err)
+		}
+	}
+
+	p.filesCreated++
+	p.lastRotation = time.Now()
+	return file, nil
+}
+
+func (p *FileProducer) closeFile(key string, file *archiveFile) {
+	if err := file.close(); err != nil {
+		p.fail(err)
+	}
+	delete(p.files, key)
+}
+
+func (p *FileProducer) fail(err error) error {
+	p.lastError = err.Error()
+	return err
+}
+
+// housekeepingLoop сбрасывает буферы сжатия, закрывает файлы прошедших часов и применяет политику хранения
+func (p *FileProducer) housekeepingLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(fileFlushInterval)
+	defer ticker.Stop()
+	lastHousekeeping, lastRetention := time.Now(), time.Now()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.flushFiles()
+			if time.Since(lastHousekeeping) < fileHousekeepingInterval {
+				continue
+			}
+			lastHousekeeping = time.Now()
+			if *p.cfg.RotateHourly {
+				p.closeStaleFiles()
+			}
+			if time.Since(lastRetention) >= fileRetentionInterval {
+				p.applyRetention()
+				lastRetention = time.Now()
+			}
+		}
+	}
+}
+
+// flushFiles сбрасывает на диск сжатые данные файлов, в которые писали после прошлого сброса
+func (p *FileProducer) flushFiles() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, file := range p.files {
+		if err := file.flush(); err != nil {
+			p.fail(fmt.Errorf("ошибка записи в %s: %w", file.path, err))
+			p.closeFile(key, file)
+		}
+	}
+}
+
+// closeStaleFiles закрывает файлы станков, от которых не было сообщений в текущем часе
+func (p *FileProducer) closeStaleFiles() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hour := time.Now().UTC().Truncate(time.Hour)
+	for key, file := range p.files {
+		if !file.hour.Equal(hour) {
+			p.closeFile(key, file)
+		}
+	}
+}
+
+// applyRetention удаляет файлы старше срока хранения, а затем самые старые файлы,
+// пока суммарный размер архива превышает ограничение. Открытые файлы не удаляются.
+func (p *FileProducer) applyRetention() {
+	if p.cfg.RetentionHours <= 0 && p.cfg.RetentionMaxMB <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	open := make(map[string]struct{}, len(p.files))
+	for _, file := range p.files {
+		open[file.path] = struct{}{}
+	}
+	p.mu.Unlock()
+
+	type archived struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []archived
+	var total int64
+	err := filepath.WalkDir(p.cfg.Dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !isArchiveFile(path) {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		total += info.Size()
+		if _, isOpen := open[path]; !isOpen {
+			files = append(files, archived{path: path, size: info.Size(), modTime: info.ModTime()})
+		}
+		return nil
+	})
+	if err != nil {
+		fileLog.Error("не удалось просмотреть каталог архива", "dir", p.cfg.Dir, logging.Err(err))
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+
+	cutoff := time.Now().Add(-time.Duration(p.cfg.RetentionHours) * time.Hour)
+	maxTotal := int64(p.cfg.RetentionMaxMB) << 20
+	var deleted uint64
+	for _, file := range files {
+		expired := p.cfg.RetentionHours > 0 && file.modTime.Before(cutoff)
+		overLimit := maxTotal > 0 && total > maxTotal
+		if !expired && !overLimit {
+			break
+		}
+		if err := os.Remove(file.path); err != nil {
+			fileLog.Error("не удалось удалить файл архива", "path", file.path, logging.Err(err))
+			continue
+		}
+		total -= file.size
+		deleted++
+	}
+
+	if deleted > 0 {
+		fileLog.Info("удалены устаревшие файлы архива", "dir", p.cfg.Dir, "files", deleted)
+		p.mu.Lock()
+		p.filesDeleted += deleted
+		p.mu.Unlock()
+	}
+}
+
+func isArchiveFile(path string) bool {
+	path = strings.TrimSuffix(path, ".gz")
+	return strings.HasSuffix(path, "."+fileFormatNDJSON) || strings.HasSuffix(path, "."+fileFormatCSV)
+}
+
+// sanitizePathSegment заменяет символы, недопустимые в имени каталога или файла
+func sanitizePathSegment(value string) string {
+	value = strings.NewReplacer("/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_", "<", "_", ">", "_", "|", "_").Replace(value)
+	if value == "" || value == "." || value == ".." {
+		return "_"
+	}
+	return value
+}
+
+// csvFields возвращает плоские поля сообщения. Payload может отсутствовать у сообщений, сохраненных
+// до его записи в outbox; тогда поля берутся из сериализованного JSON.
+func csvFields(msg *entities.Message) map[string]interface{} {
+	if msg.Payload == nil && json.Valid(msg.Value) {
+		return entities.FlattenValue(json.RawMessage(msg.Value))
+	}
+	return entities.FlattenValue(msg.Payload)
+}
+
+// csvRow формирует строку CSV: значения постоянных колонок, затем JSON-объект остальных полей
+func csvRow(columns []string, fields map[string]interface{}) []string {
+	row := make([]string, 0, len(columns)+1)
+	rest := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		rest[name] = value
+	}
+	for _, column := range columns {
+		row = append(row, formatCSVValue(rest[column]))
+		delete(rest, column)
+	}
+	extra := ""
+	if len(rest) > 0 {
+		raw, _ := json.Marshal(rest)
+		extra = string(raw)
+	}
+	return append(row, extra)
+}
+
+// formatCSVValue приводит плоское значение к строке ячейки CSV
+func formatCSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}, []interface{}:
+		return ""
+	default:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+}
+
+// archiveFile - открытый файл архива
+type archiveFile struct {
+	path    string
+	file    *os.File
+	counter *countingWriter
+	gz      *gzip.Writer
+	out     io.Writer
+	hour    time.Time
+
+	csv     *csv.Writer
+	columns []string
+	// dirty - в поток gzip писали после последнего сброса
+	dirty bool
+}
+
+// write дописывает одну запись. Строки CSV сразу передаются в файл (или поток gzip),
+// а сжатые данные сбрасываются периодически, см. flush.
+func (f *archiveFile) write(msg *entities.Message, fields map[string]interface{}) (int, error) {
+	before := f.counter.n
+	if f.csv != nil {
+		if err := f.csv.Write(csvRow(f.columns, fields)); err != nil {
+			return 0, err
+		}
+		f.csv.Flush()
+		if err := f.csv.Error(); err != nil {
+			return 0, err
+		}
+	} else {
+		line := make([]byte, 0, len(msg.Value)+1)
+		line = append(append(line, msg.Value...), '\n')
+		if _, err := f.out.Write(line); err != nil {
+			return 0, err
+		}
+	}
+	f.dirty = true
+	return int(f.counter.n - before), nil
+}
+
+// flush сбрасывает буфер gzip, чтобы записанные строки можно было прочитать из файла после сбоя
+func (f *archiveFile) flush() error {
+	if f.gz == nil || !f.dirty {
+		return nil
+	}
+	f.dirty = false
+	return f.gz.Flush()
+}
+
+func (f *archiveFile) close() error {
+	var errs []error
+	if f.gz != nil {
+		errs = append(errs, f.gz.Close())
+	}
+	errs = append(errs, f.file.Close())
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("ошибка закрытия файла %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// countingWriter считает байты, записанные в файл (после сжатия)
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,98 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileProducerCSVKeepsColumnsPerMessageType(t *testing.T) {
+	dir := t.TempDir()
+	rotateHourly := false
+	producer, err := NewFileSink(config.SinkConfig{Name: "archive", File: &config.FileSinkConfig{
+		Dir:          dir,
+		Format:       fileFormatCSV,
+		PathTemplate: "{machineId}",
+		RotateMaxMB:  64,
+		RotateHourly: &rotateHourly,
+		Gzip:         true,
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := testMachineData()
+	second := testMachineData()
+	second.Id = "d2"
+	second.CurrentProgram = &entities.CurrentProgramInfo{Program: "O1000"}
+	value, err := json.Marshal(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := []*entities.Message{
+		{MachineID: "Mazak", Type: entities.MessageTypeSnapshot, Payload: first},
+		// Сообщение из outbox старого формата: Payload нет, поля берутся из JSON
+		{MachineID: "Mazak", Type: entities.MessageTypeSnapshot, Value: value, ContentType: contentTypeJSON},
+	}
+	for _, msg := range messages {
+		if err := producer.Produce(context.Background(), msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := producer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "Mazak", "*.csv.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("файлов архива: %v, ожидался один - новые поля не должны начинать новый файл", paths)
+	}
+	f, err := os.Open(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(gz).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("строк в файле: %d, ожидались заголовок и две записи", len(rows))
+	}
+
+	header := rows[0]
+	wantHeader := append(append([]string(nil), csvColumnsByType[entities.MessageTypeSnapshot]...), csvFieldsColumn)
+	if strings.Join(header, ",") != strings.Join(wantHeader, ",") {
+		t.Errorf("заголовок = %v, ожидался %v", header, wantHeader)
+	}
+	for i, want := range []string{"d1", "d2"} {
+		row := rows[i+1]
+		if row[1] != want || row[0] != "Mazak" || row[3] != "true" || row[5] != "ACTIVE" {
+			t.Errorf("запись #%d: %v", i+1, row)
+		}
+		var extra map[string]interface{}
+		if err := json.Unmarshal([]byte(row[len(row)-1]), &extra); err != nil {
+			t.Fatalf("колонка %s записи #%d не содержит JSON: %v", csvFieldsColumn, i+1, err)
+		}
+		if extra["FeedRate.VALUE"] != "100.5" {
+			t.Errorf("колонка %s записи #%d: %v", csvFieldsColumn, i+1, extra)
+		}
+	}
+	if !strings.Contains(rows[2][len(rows[2])-1], `"CurrentProgram.PROGRAM":"O1000"`) {
+		t.Errorf("новое поле не попало в колонку %s: %s", csvFieldsColumn, rows[2][len(rows[2])-1])
+	}
+}
@@ -2,36 +2,218 @@ package producers
 
 import (
 	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
 	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 )
 
+var kafkaLog = logging.Logger(logging.ComponentKafka)
+
 type KafkaProducer struct {
 	writer *kafka.Writer
+	router *kafkaTopicRouter
+	// topics создает топики перед первой отправкой, nil если автосоздание отключено
+	topics *kafkaTopicCreator
+	// client запрашивает метаданные кластера через транспорт писателя для проверки готовности
+	client *kafka.Client
+	// fallback сохраняет сообщения, доставка которых не удалась в асинхронном режиме
+	fallback func(msg *entities.Message) error
+	// onDelivered подтверждает доставку сообщений в асинхронном режиме
+	onDelivered func(count int)
+}
+
+// NewKafkaSink создает продюсер Kafka для синка
+func NewKafkaSink(sink config.SinkConfig, _ *config.AppConfig) (interfaces.DataProducer, error) {
+	if sink.Kafka == nil {
+		return nil, fmt.Errorf("для синка '%s' не задан раздел kafka", sink.Name)
+	}
+	if len(sink.Kafka.Brokers) == 0 {
+		return nil, fmt.Errorf("для синка '%s' не заданы брокеры Kafka", sink.Name)
+	}
+	router, err := newKafkaTopicRouter(*sink.Kafka)
+	if err != nil {
+		return nil, fmt.Errorf("синк '%s': %w", sink.Name, err)
+	}
+	producer := &KafkaProducer{router: router}
+	writer, err := newKafkaWriter(*sink.Kafka, producer.handleAsyncCompletion)
+	if err != nil {
+		return nil, err
+	}
+	producer.writer = writer
+	producer.client = &kafka.Client{Addr: writer.Addr, Transport: writer.Transport}
+	if create := sink.Kafka.AutoCreateTopics; create != nil && create.Enabled {
+		producer.topics = newKafkaTopicCreator(*sink.Kafka, writer.Transport)
+	}
+	return producer, nil
+}
+
+// Async сообщает, подтверждается ли доставка после возврата из Produce
+func (p *KafkaProducer) Async() bool {
+	return p.writer.Async
+}
+
+// SetFallback задает обработчик сообщений, доставка которых не удалась в асинхронном режиме
+func (p *KafkaProducer) SetFallback(fallback func(msg *entities.Message) error) {
+	p.fallback = fallback
+}
+
+// SetDeliveryHandler задает обработчик подтверждения доставки в асинхронном режиме
+func (p *KafkaProducer) SetDeliveryHandler(onDelivered func(count int)) {
+	p.onDelivered = onDelivered
 }
 
-// NewKafkaProducer создает новый экземпляр продюсера Kafka
-func NewKafkaProducer(cfg *config.AppConfig) (interfaces.DataProducer, error) {
+// handleAsyncCompletion вызывается kafka-go после асинхронной отправки пакета
+func (p *KafkaProducer) handleAsyncCompletion(messages []kafka.Message, err error) {
+	if err == nil {
+		if p.onDelivered != nil {
+			p.onDelivered(len(messages))
+		}
+		return
+	}
+	if p.fallback == nil {
+		kafkaLog.Error("асинхронная отправка сообщений не удалась", "messages", len(messages), logging.Err(err))
+		return
+	}
+	for _, message := range messages {
+		msg, ok := message.WriterData.(*entities.Message)
+		if !ok {
+			continue
+		}
+		if persistErr := p.fallback(msg); persistErr != nil {
+			kafkaLog.Error("сообщение потеряно", "topic", message.Topic, logging.Err(persistErr))
+		}
+	}
+}
+
+// newKafkaWriter настраивает kafka.Writer по конфигурации синка.
+// Сообщения распределяются по партициям хешем ключа (идентификатора станка),
+// поэтому сообщения одного станка сохраняют порядок.
+func newKafkaWriter(cfg config.KafkaSinkConfig, onAsyncCompletion func([]kafka.Message, error)) (*kafka.Writer, error) {
+	acks, err := parseRequiredAcks(cfg.RequiredAcks)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := parseCompression(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := newKafkaTransport(cfg.TLS, cfg.SASL)
+	if err != nil {
+		return nil, err
+	}
+
 	writer := &kafka.Writer{
-		Addr:     kafka.TCP(cfg.KafkaBrokers...),
-		Topic:    cfg.KafkaTopic,
-		Balancer: &kafka.LeastBytes{},
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: acks,
+		Compression:  compression,
+		BatchSize:    cfg.BatchSize,
+		BatchTimeout: time.Duration(cfg.BatchTimeoutMs) * time.Millisecond,
+		Async:        cfg.Async,
+		// Если брокер не дает создать топик с параметрами, он может создать его со своими настройками
+		AllowAutoTopicCreation: cfg.AutoCreateTopics != nil && cfg.AutoCreateTopics.Enabled,
+	}
+	if transport != nil {
+		writer.Transport = transport
+	}
+	if cfg.Async {
+		writer.Completion = onAsyncCompletion
+	}
+	return writer, nil
+}
+
+func parseRequiredAcks(value string) (kafka.RequiredAcks, error) {
+	switch strings.ToLower(value) {
+	case "none", "0":
+		return kafka.RequireNone, nil
+	case "", "one", "1":
+		return kafka.RequireOne, nil
+	case "all", "-1":
+		return kafka.RequireAll, nil
 	}
-	return &KafkaProducer{writer: writer}, nil
+	return 0, fmt.Errorf("неизвестное значение required_acks: '%s'", value)
+}
+
+func parseCompression(value string) (kafka.Compression, error) {
+	switch strings.ToLower(value) {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	}
+	return 0, fmt.Errorf("неизвестный кодек compression: '%s'", value)
 }
 
 // Produce отправляет сообщение в Kafka
-func (p *KafkaProducer) Produce(ctx context.Context, key, value []byte) error {
+func (p *KafkaProducer) Produce(ctx context.Context, msg *entities.Message) error {
+	topic := p.router.topicFor(msg)
+	if topic == "" {
+		return fmt.Errorf("не задан топик для сообщений типа '%s'", msg.Type)
+	}
+	if p.topics != nil {
+		p.topics.ensure(ctx, topic)
+	}
 	return p.writer.WriteMessages(ctx,
 		kafka.Message{
-			Key:   key,
-			Value: value,
+			Topic:      topic,
+			Key:        []byte(msg.Key),
+			Value:      msg.Value,
+			Headers:    kafkaHeaders(msg.Headers, msg.ContentType),
+			WriterData: msg,
 		},
 	)
 }
 
+// kafkaHeaders переносит заголовки сообщения в Kafka и добавляет content-type,
+// чтобы потребитель мог определить формат без schema registry
+func kafkaHeaders(headers map[string]string, contentType string) []kafka.Header {
+	keys := make([]string, 0, len(headers)+1)
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	if _, ok := headers["content-type"]; !ok && contentType != "" {
+		keys = append(keys, "content-type")
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	result := make([]kafka.Header, 0, len(keys))
+	for _, k := range keys {
+		value, ok := headers[k]
+		if !ok {
+			value = contentType
+		}
+		result = append(result, kafka.Header{Key: k, Value: []byte(value)})
+	}
+	return result
+}
+
+// CheckHealth проверяет связь писателя с кластером запросом метаданных брокеров
+func (p *KafkaProducer) CheckHealth(ctx context.Context) error {
+	metadata, err := p.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{}})
+	if err != nil {
+		return fmt.Errorf("кластер Kafka недоступен: %w", err)
+	}
+	if len(metadata.Brokers) == 0 {
+		return fmt.Errorf("кластер Kafka не вернул ни одного брокера")
+	}
+	return nil
+}
+
 // Close закрывает соединение с Kafka
 func (p *KafkaProducer) Close() error {
 	return p.writer.Close()
@@ -0,0 +1,52 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// newKafkaTransport создает транспорт с TLS и SASL. Если ни то, ни другое не настроено,
+// возвращается nil, и kafka-go использует транспорт по умолчанию.
+func newKafkaTransport(tlsCfg config.TLSConfig, saslCfg config.KafkaSASLConfig) (*kafka.Transport, error) {
+	if !tlsCfg.Enabled && saslCfg.Mechanism == "" {
+		return nil, nil
+	}
+
+	transport := &kafka.Transport{
+		DialTimeout: 10 * time.Second,
+	}
+	if tlsCfg.Enabled {
+		tlsConfig, err := newTLSConfig(tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLS = tlsConfig
+	}
+	if saslCfg.Mechanism != "" {
+		mechanism, err := newSASLMechanism(saslCfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.SASL = mechanism
+	}
+	return transport, nil
+}
+
+func newSASLMechanism(cfg config.KafkaSASLConfig) (sasl.Mechanism, error) {
+	switch strings.ToLower(cfg.Mechanism) {
+	case "plain":
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, cfg.Username, cfg.Password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, cfg.Username, cfg.Password)
+	}
+	return nil, fmt.Errorf("неподдерживаемый механизм SASL: '%s'", cfg.Mechanism)
+}
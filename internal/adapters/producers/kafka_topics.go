@@ -0,0 +1,178 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/logging"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// kafkaTopicMaxLength - ограничение длины имени топика в Kafka
+	kafkaTopicMaxLength = 249
+	// kafkaTopicCreateRetry - пауза перед повторной попыткой создать топик после сетевой ошибки
+	kafkaTopicCreateRetry   = 30 * time.Second
+	kafkaTopicCreateTimeout = 10 * time.Second
+)
+
+// kafkaTopicRouter выбирает топик сообщения: правила станков, затем шаблон типа сообщения.
+// Топик одного типа не используется для сообщений других типов.
+type kafkaTopicRouter struct {
+	topics map[string]string
+	routes []config.KafkaRouteConfig
+}
+
+func newKafkaTopicRouter(cfg config.KafkaSinkConfig) (*kafkaTopicRouter, error) {
+	for i, route := range cfg.Routes {
+		if route.Topic == "" {
+			return nil, fmt.Errorf("в правиле маршрутизации #%d не задан топик", i+1)
+		}
+		for _, pattern := range append(append([]string{}, route.MachineIDs...), route.Manufacturers...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("некорректный шаблон '%s' в правиле маршрутизации #%d: %w", pattern, i+1, err)
+			}
+		}
+	}
+	if cfg.Topic == "" && len(cfg.Topics) == 0 && len(cfg.Routes) == 0 {
+		return nil, fmt.Errorf("не задан топик Kafka")
+	}
+	topics := make(map[string]string, len(cfg.Topics)+1)
+	for messageType, topic := range cfg.Topics {
+		topics[messageType] = topic
+	}
+	if _, ok := topics[entities.MessageTypeSnapshot]; !ok && cfg.Topic != "" {
+		topics[entities.MessageTypeSnapshot] = cfg.Topic
+	}
+	return &kafkaTopicRouter{topics: topics, routes: cfg.Routes}, nil
+}
+
+// topicFor возвращает имя топика; пустая строка означает, что топик для сообщения не настроен
+func (r *kafkaTopicRouter) topicFor(msg *entities.Message) string {
+	template := r.topics[msg.Type]
+	for _, route := range r.routes {
+		if routeMatches(route, msg) {
+			template = route.Topic
+			break
+		}
+	}
+	topic := renderTopic(template, msg, sanitizeKafkaTopic)
+	if len(topic) > kafkaTopicMaxLength {
+		topic = topic[:kafkaTopicMaxLength]
+	}
+	return topic
+}
+
+// sanitizeKafkaTopic заменяет символы, недопустимые в имени топика Kafka ([a-zA-Z0-9._-])
+func sanitizeKafkaTopic(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		}
+		return '_'
+	}, value)
+}
+
+// kafkaTopicCreator создает топики перед первой отправкой в них.
+// Отказ брокера в правах отключает создание: дальше используются существующие топики.
+type kafkaTopicCreator struct {
+	client *kafka.Client
+	cfg    config.KafkaTopicCreateConfig
+
+	mu       sync.Mutex
+	ready    map[string]bool
+	retryAt  map[string]time.Time
+	disabled bool
+}
+
+// newKafkaTopicCreator использует транспорт писателя, чтобы разделить с ним настройки TLS и SASL
+func newKafkaTopicCreator(cfg config.KafkaSinkConfig, transport kafka.RoundTripper) *kafkaTopicCreator {
+	return &kafkaTopicCreator{
+		client:  &kafka.Client{Addr: kafka.TCP(cfg.Brokers...), Transport: transport, Timeout: kafkaTopicCreateTimeout},
+		cfg:     *cfg.AutoCreateTopics,
+		ready:   make(map[string]bool),
+		retryAt: make(map[string]time.Time),
+	}
+}
+
+// ensure создает топик, если он еще не проверялся. Ошибки не прерывают отправку:
+// сообщение уходит в топик в любом случае, и брокер сам решает, принять ли его.
+func (c *kafkaTopicCreator) ensure(ctx context.Context, topic string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.disabled || c.ready[topic] || time.Now().Before(c.retryAt[topic]) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, kafkaTopicCreateTimeout)
+	defer cancel()
+	response, err := c.client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{{
+			Topic:             topic,
+			NumPartitions:     c.cfg.Partitions,
+			ReplicationFactor: c.cfg.ReplicationFactor,
+			ConfigEntries:     c.configEntries(),
+		}},
+	})
+	if err == nil {
+		err = response.Errors[topic]
+	}
+
+	switch {
+	case err == nil:
+		kafkaLog.Info("создан топик", "topic", topic, "partitions", c.cfg.Partitions, "replicationFactor", c.cfg.ReplicationFactor)
+		c.ready[topic] = true
+	case errors.Is(err, kafka.TopicAlreadyExists):
+		c.ready[topic] = true
+	case errors.Is(err, kafka.TopicAuthorizationFailed), errors.Is(err, kafka.ClusterAuthorizationFailed),
+		errors.Is(err, kafka.PolicyViolation), errors.Is(err, kafka.UnsupportedVersion):
+		kafkaLog.Warn("брокер не разрешает создавать топики, автосоздание отключено", logging.Err(err))
+		c.disabled = true
+	default:
+		kafkaLog.Warn("не удалось создать топик", "topic", topic, logging.Err(err))
+		c.retryAt[topic] = time.Now().Add(kafkaTopicCreateRetry)
+	}
+}
+
+func (c *kafkaTopicCreator) configEntries() []kafka.ConfigEntry {
+	entries := make([]kafka.ConfigEntry, 0, len(c.cfg.Configs)+1)
+	if c.cfg.RetentionHours > 0 {
+		retention := time.Duration(c.cfg.RetentionHours) * time.Hour
+		entries = append(entries, kafka.ConfigEntry{ConfigName: "retention.ms", ConfigValue: strconv.FormatInt(retention.Milliseconds(), 10)})
+	}
+	for name, value := range c.cfg.Configs {
+		entries = append(entries, kafka.ConfigEntry{ConfigName: name, ConfigValue: value})
+	}
+	return entries
+}
+
+// routeMatches проверяет, подходит ли сообщение под правило маршрутизации
+func routeMatches(route config.KafkaRouteConfig, msg *entities.Message) bool {
+	if len(route.MessageTypes) > 0 && !slices.Contains(route.MessageTypes, msg.Type) {
+		return false
+	}
+	return matchesAnyPattern(route.MachineIDs, msg.MachineID) && matchesAnyPattern(route.Manufacturers, msg.Manufacturer)
+}
+
+// matchesAnyPattern возвращает true для пустого списка шаблонов
+func matchesAnyPattern(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"testing"
+)
+
+func TestKafkaTopicRouterDoesNotFallBackToSnapshotTopic(t *testing.T) {
+	router, err := newKafkaTopicRouter(config.KafkaSinkConfig{
+		Topic:  "plant_data",
+		Topics: map[string]string{entities.MessageTypeAlarm: "plant.{manufacturer}.alarms"},
+		Routes: []config.KafkaRouteConfig{{MachineIDs: []string{"VTC-*"}, MessageTypes: []string{entities.MessageTypeSnapshot}, Topic: "vtc_data"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		msg  entities.Message
+		want string
+	}{
+		{entities.Message{Type: entities.MessageTypeSnapshot, MachineID: "Mazak"}, "plant_data"},
+		{entities.Message{Type: entities.MessageTypeSnapshot, MachineID: "VTC-1"}, "vtc_data"},
+		{entities.Message{Type: entities.MessageTypeAlarm, MachineID: "Mazak", Manufacturer: "Mazak"}, "plant.Mazak.alarms"},
+		// Типы без своего топика не попадают в топик снимков
+		{entities.Message{Type: entities.MessageTypeLifecycle, MachineID: "Mazak"}, ""},
+		{entities.Message{Type: entities.MessageTypeDelta, MachineID: "Mazak"}, ""},
+		{entities.Message{Type: entities.MessageTypeObservation, MachineID: "Mazak"}, ""},
+	}
+	for _, test := range tests {
+		if got := router.topicFor(&test.msg); got != test.want {
+			t.Errorf("topicFor(%s, %s) = '%s', ожидался '%s'", test.msg.Type, test.msg.MachineID, got, test.want)
+		}
+	}
+}
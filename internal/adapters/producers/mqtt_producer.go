@@ -0,0 +1,232 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+var mqttLog = logging.Logger(logging.ComponentMQTT)
+
+const (
+	mqttStatusOnline  = "online"
+	mqttStatusOffline = "offline"
+)
+
+// mqttClient - общий контракт клиентов MQTT 3.1.1 и MQTT 5
+type mqttClient interface {
+	Publish(ctx context.Context, topic string, qos byte, retain bool, payload []byte, msg *entities.Message) error
+	Close(ctx context.Context) error
+}
+
+// MQTTProducer публикует сообщения в MQTT-брокер в иерархию топиков по станкам
+type MQTTProducer struct {
+	cfg    config.MQTTSinkConfig
+	client mqttClient
+}
+
+// NewMQTTSink создает продюсер MQTT для синка
+func NewMQTTSink(sink config.SinkConfig, _ *config.AppConfig) (interfaces.DataProducer, error) {
+	if sink.MQTT == nil {
+		return nil, fmt.Errorf("для синка '%s' не задан раздел mqtt", sink.Name)
+	}
+	cfg := *sink.MQTT
+	if cfg.BrokerURL == "" {
+		return nil, fmt.Errorf("для синка '%s' не задан broker_url", sink.Name)
+	}
+	if cfg.QoS > 2 {
+		return nil, fmt.Errorf("недопустимый уровень QoS %d для синка '%s'", cfg.QoS, sink.Name)
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.TLS.Enabled {
+		var err error
+		if tlsConfig, err = newTLSConfig(cfg.TLS); err != nil {
+			return nil, err
+		}
+	}
+
+	var client mqttClient
+	var err error
+	switch cfg.ProtocolVersion {
+	case "3.1.1", "4":
+		client = newMQTTv311Client(cfg, tlsConfig)
+	case "5":
+		client, err = newMQTTv5Client(cfg, tlsConfig)
+	default:
+		err = fmt.Errorf("неподдерживаемая версия протокола MQTT: '%s'", cfg.ProtocolVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &MQTTProducer{cfg: cfg, client: client}, nil
+}
+
+// Produce публикует сообщение в топик, соответствующий его типу.
+// Снимки состояния публикуются с флагом retain, чтобы новый подписчик сразу получал последнее состояние.
+func (p *MQTTProducer) Produce(ctx context.Context, msg *entities.Message) error {
+	if !p.Routes(msg.Type) {
+		return fmt.Errorf("для сообщений типа '%s' не задан топик MQTT", msg.Type)
+	}
+	template := p.cfg.Topics[msg.Type]
+	topic := renderTopic(template, msg, sanitizeMQTTTopicLevel)
+	retain := msg.Type == entities.MessageTypeSnapshot && *p.cfg.RetainState
+	return p.client.Publish(ctx, topic, p.cfg.QoS, retain, msg.Value, msg)
+}
+
+// Routes сообщает, задан ли топик для сообщений типа messageType.
+// Сообщения остальных типов отсеиваются фильтром синка и не учитываются как доставленные.
+func (p *MQTTProducer) Routes(messageType string) bool {
+	return p.cfg.Topics[messageType] != ""
+}
+
+// Close публикует статус offline и отключается от брокера
+func (p *MQTTProducer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return p.client.Close(ctx)
+}
+
+// --- MQTT 3.1.1 ---
+
+type mqttV311Client struct {
+	cfg    config.MQTTSinkConfig
+	client mqtt.Client
+}
+
+func newMQTTv311Client(cfg config.MQTTSinkConfig, tlsConfig *tls.Config) *mqttV311Client {
+	opts := mqtt.NewClientOptions().
+		AddBroker(cfg.BrokerURL).
+		SetClientID(cfg.ClientID).
+		SetUsername(cfg.Username).
+		SetPassword(cfg.Password).
+		SetProtocolVersion(4).
+		SetKeepAlive(time.Duration(cfg.KeepAliveSec)*time.Second).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(5*time.Second).
+		SetWill(cfg.StatusTopic, mqttStatusOffline, cfg.QoS, true)
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+	opts.SetOnConnectHandler(func(client mqtt.Client) {
+		mqttLog.Info("подключено к брокеру", "broker", cfg.BrokerURL)
+		client.Publish(cfg.StatusTopic, cfg.QoS, true, mqttStatusOnline)
+	})
+	opts.SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+		mqttLog.Warn("потеряно соединение с брокером", "broker", cfg.BrokerURL, logging.Err(err))
+	})
+
+	client := mqtt.NewClient(opts)
+	// При SetConnectRetry подключение выполняется в фоне, запуск сервиса не блокируется
+	client.Connect()
+	return &mqttV311Client{cfg: cfg, client: client}
+}
+
+func (c *mqttV311Client) Publish(ctx context.Context, topic string, qos byte, retain bool, payload []byte, _ *entities.Message) error {
+	if !c.client.IsConnectionOpen() {
+		return fmt.Errorf("нет соединения с MQTT-брокером %s", c.cfg.BrokerURL)
+	}
+	token := c.client.Publish(topic, qos, retain, payload)
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return fmt.Errorf("превышено время ожидания публикации в %s: %w", topic, ctx.Err())
+	}
+}
+
+func (c *mqttV311Client) Close(ctx context.Context) error {
+	if c.client.IsConnectionOpen() {
+		token := c.client.Publish(c.cfg.StatusTopic, c.cfg.QoS, true, mqttStatusOffline)
+		select {
+		case <-token.Done():
+		case <-ctx.Done():
+		}
+	}
+	c.client.Disconnect(250)
+	return nil
+}
+
+// --- MQTT 5 ---
+
+type mqttV5Client struct {
+	cfg  config.MQTTSinkConfig
+	conn *autopaho.ConnectionManager
+}
+
+func newMQTTv5Client(cfg config.MQTTSinkConfig, tlsConfig *tls.Config) (*mqttV5Client, error) {
+	brokerURL, err := url.Parse(cfg.BrokerURL)
+	if err != nil {
+		return nil, fmt.Errorf("некорректный broker_url '%s': %w", cfg.BrokerURL, err)
+	}
+	clientCfg := autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{brokerURL},
+		TlsCfg:                        tlsConfig,
+		KeepAlive:                     uint16(cfg.KeepAliveSec),
+		CleanStartOnInitialConnection: true,
+		ConnectUsername:               cfg.Username,
+		ConnectPassword:               []byte(cfg.Password),
+		WillMessage: &paho.WillMessage{
+			Topic:   cfg.StatusTopic,
+			Payload: []byte(mqttStatusOffline),
+			QoS:     cfg.QoS,
+			Retain:  true,
+		},
+		OnConnectionUp: func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+			mqttLog.Info("подключено к брокеру", "broker", cfg.BrokerURL)
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if _, err := cm.Publish(ctx, &paho.Publish{Topic: cfg.StatusTopic, QoS: cfg.QoS, Retain: true, Payload: []byte(mqttStatusOnline)}); err != nil {
+					mqttLog.Warn("не удалось опубликовать статус online", "broker", cfg.BrokerURL, logging.Err(err))
+				}
+			}()
+		},
+		OnConnectError: func(err error) {
+			mqttLog.Warn("ошибка подключения к брокеру", "broker", cfg.BrokerURL, logging.Err(err))
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: cfg.ClientID,
+		},
+	}
+	if cfg.Username == "" {
+		clientCfg.ConnectPassword = nil
+	}
+
+	conn, err := autopaho.NewConnection(context.Background(), clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать подключение MQTT: %w", err)
+	}
+	return &mqttV5Client{cfg: cfg, conn: conn}, nil
+}
+
+func (c *mqttV5Client) Publish(ctx context.Context, topic string, qos byte, retain bool, payload []byte, msg *entities.Message) error {
+	properties := &paho.PublishProperties{ContentType: msg.ContentType}
+	for key, value := range msg.Headers {
+		properties.User.Add(key, value)
+	}
+	_, err := c.conn.Publish(ctx, &paho.Publish{
+		Topic:      topic,
+		QoS:        qos,
+		Retain:     retain,
+		Payload:    payload,
+		Properties: properties,
+	})
+	return err
+}
+
+func (c *mqttV5Client) Close(ctx context.Context) error {
+	_, _ = c.conn.Publish(ctx, &paho.Publish{Topic: c.cfg.StatusTopic, QoS: c.cfg.QoS, Retain: true, Payload: []byte(mqttStatusOffline)})
+	return c.conn.Disconnect(ctx)
+}
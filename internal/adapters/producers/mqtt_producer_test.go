@@ -0,0 +1,234 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+)
+
+// mqttReceived - сообщение, полученное встроенным брокером
+type mqttReceived struct {
+	topic   string
+	payload string
+	retain  bool
+	user    map[string]string
+}
+
+// mqttRecorder собирает сообщения подписки встроенного брокера
+type mqttRecorder struct {
+	mu       sync.Mutex
+	messages []mqttReceived
+}
+
+func (r *mqttRecorder) handle(_ *mochi.Client, _ packets.Subscription, pk packets.Packet) {
+	user := make(map[string]string, len(pk.Properties.User))
+	for _, property := range pk.Properties.User {
+		user[property.Key] = property.Val
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, mqttReceived{topic: pk.TopicName, payload: string(pk.Payload), retain: pk.FixedHeader.Retain, user: user})
+}
+
+// waitFor ждет сообщение в топике с заданным содержимым
+func (r *mqttRecorder) waitFor(t *testing.T, topic, payload string) mqttReceived {
+	t.Helper()
+	return r.waitForNth(t, topic, payload, 1)
+}
+
+// waitForNth ждет n-е сообщение в топике с заданным содержимым
+func (r *mqttRecorder) waitForNth(t *testing.T, topic, payload string, n int) mqttReceived {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		found := 0
+		for _, msg := range r.messages {
+			if msg.topic == topic && msg.payload == payload {
+				if found++; found == n {
+					r.mu.Unlock()
+					return msg
+				}
+			}
+		}
+		r.mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("брокер не получил '%s' в топике %s (ожидалось сообщений: %d)", payload, topic, n)
+	return mqttReceived{}
+}
+
+// startMQTTBroker запускает брокер mochi-mqtt в процессе теста и возвращает его адрес
+func startMQTTBroker(t *testing.T) (*mochi.Server, string) {
+	t.Helper()
+	server := mochi.New(&mochi.Options{InlineClient: true, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err := server.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatal(err)
+	}
+	listener := listeners.NewTCP(listeners.Config{ID: "tcp", Address: "127.0.0.1:0"})
+	if err := server.AddListener(listener); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = server.Close() })
+	return server, "tcp://" + listener.Address()
+}
+
+func TestMQTTProducerEmbeddedBroker(t *testing.T) {
+	for _, version := range []string{"3.1.1", "5"} {
+		t.Run("v"+version, func(t *testing.T) {
+			server, brokerURL := startMQTTBroker(t)
+			live := &mqttRecorder{}
+			if err := server.Subscribe("#", 1, live.handle); err != nil {
+				t.Fatal(err)
+			}
+
+			retain := true
+			cfg := config.MQTTSinkConfig{
+				BrokerURL:       brokerURL,
+				ClientID:        "mtconnect-test-" + version,
+				ProtocolVersion: version,
+				QoS:             1,
+				RetainState:     &retain,
+				Topics: map[string]string{
+					entities.MessageTypeSnapshot: "mtconnect/{manufacturer}/{machineId}/state",
+					entities.MessageTypeAlarm:    "mtconnect/{manufacturer}/{machineId}/alarms",
+				},
+				StatusTopic:  "mtconnect/_service/test/status",
+				KeepAliveSec: 30,
+			}
+			producer, err := NewMQTTSink(config.SinkConfig{Name: "mqtt", Type: config.SinkTypeMQTT, MQTT: &cfg}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer producer.Close()
+
+			// Статус online публикуется при подключении с флагом retain
+			if status := live.waitFor(t, cfg.StatusTopic, mqttStatusOnline); !status.retain {
+				t.Error("статус online опубликован без retain")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			snapshot := &entities.Message{
+				Type:         entities.MessageTypeSnapshot,
+				MachineID:    "VTC/300#1",
+				Manufacturer: "Mazak",
+				Value:        []byte(`{"machineId":"VTC/300#1"}`),
+				ContentType:  "application/json",
+				Headers:      map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			}
+			alarm := &entities.Message{Type: entities.MessageTypeAlarm, MachineID: "VTC/300#1", Value: []byte(`{"alarm":1}`)}
+			for _, msg := range []*entities.Message{snapshot, alarm} {
+				if err := producer.Produce(ctx, msg); err != nil {
+					t.Fatalf("Produce(%s): %v", msg.Type, err)
+				}
+			}
+			unrouted := &entities.Message{Type: entities.MessageTypeObservation, MachineID: "VTC/300#1", Value: []byte(`{}`)}
+			if err := producer.Produce(ctx, unrouted); err == nil {
+				t.Error("Produce сообщения без топика не вернул ошибку")
+			}
+
+			// Недопустимые в уровне топика символы заменяются, пустой производитель - unknown
+			stateTopic := "mtconnect/Mazak/VTC_300_1/state"
+			state := live.waitFor(t, stateTopic, string(snapshot.Value))
+			alarms := live.waitFor(t, "mtconnect/unknown/VTC_300_1/alarms", string(alarm.Value))
+			if alarms.retain {
+				t.Error("событие аварии опубликовано с retain")
+			}
+			if version == "5" && state.user["traceparent"] != snapshot.Headers["traceparent"] {
+				t.Errorf("user properties MQTT 5: %v, ожидался traceparent", state.user)
+			}
+
+			// Новый подписчик сразу получает последний снимок, сохраненный брокером
+			retained := &mqttRecorder{}
+			if err := server.Subscribe("mtconnect/+/+/state", 2, retained.handle); err != nil {
+				t.Fatal(err)
+			}
+			if msg := retained.waitFor(t, stateTopic, string(snapshot.Value)); !msg.retain {
+				t.Error("снимок состояния не сохранен брокером")
+			}
+
+			// При обрыве соединения брокер публикует last will - статус offline
+			client, ok := server.Clients.Get(cfg.ClientID)
+			if !ok {
+				t.Fatalf("клиент %s не подключен к брокеру", cfg.ClientID)
+			}
+			client.Stop(errors.New("обрыв соединения в тесте"))
+			if status := live.waitFor(t, cfg.StatusTopic, mqttStatusOffline); !status.retain {
+				t.Error("last will опубликован без retain")
+			}
+			// Продюсер переподключается и снова сообщает online
+			live.waitForNth(t, cfg.StatusTopic, mqttStatusOnline, 2)
+		})
+	}
+}
+
+func TestMQTTSinkSkipsUnroutedMessageTypes(t *testing.T) {
+	server, brokerURL := startMQTTBroker(t)
+	live := &mqttRecorder{}
+	if err := server.Subscribe("mtconnect/#", 1, live.handle); err != nil {
+		t.Fatal(err)
+	}
+
+	outbox, retain := false, true
+	cfg := &config.AppConfig{Sinks: []config.SinkConfig{{
+		Name:        "mqtt",
+		Type:        config.SinkTypeMQTT,
+		CloudEvents: "none",
+		QueueSize:   10,
+		Outbox:      &outbox,
+		MQTT: &config.MQTTSinkConfig{
+			BrokerURL:       brokerURL,
+			ClientID:        "mtconnect-test-routes",
+			ProtocolVersion: "5",
+			QoS:             1,
+			RetainState:     &retain,
+			Topics:          map[string]string{entities.MessageTypeSnapshot: "mtconnect/{machineId}/state"},
+			StatusTopic:     "mtconnect/_service/routes/status",
+			KeepAliveSec:    30,
+		},
+	}}}
+	registry, err := NewSinkRegistry(cfg, NewOutboxRegistry(), nopSinkMetrics{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	live.waitFor(t, cfg.Sinks[0].MQTT.StatusTopic, mqttStatusOnline)
+	for _, msg := range []*entities.Message{
+		{ID: "o1", Type: entities.MessageTypeObservation, MachineID: "Mazak", Payload: entities.Observation{MachineId: "Mazak"}},
+		{ID: "s1", Type: entities.MessageTypeSnapshot, MachineID: "Mazak", Payload: testMachineData()},
+	} {
+		if err := registry.Produce(context.Background(), msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := registry.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Наблюдение без топика отсеяно фильтром: не доставлено и не учтено как доставленное
+	stats := registry.SinkStats()[0]
+	if stats.Delivered != 1 || stats.Failed != 0 {
+		t.Errorf("Delivered = %d, Failed = %d, ожидалось 1 и 0: %s", stats.Delivered, stats.Failed, stats.LastError)
+	}
+	live.mu.Lock()
+	defer live.mu.Unlock()
+	for _, msg := range live.messages {
+		if msg.topic != "mtconnect/Mazak/state" && msg.topic != "mtconnect/_service/routes/status" {
+			t.Errorf("опубликовано сообщение в неожиданный топик %s", msg.topic)
+		}
+	}
+}
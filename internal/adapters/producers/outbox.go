@@ -0,0 +1,391 @@
+package producers
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var outboxLog = logging.Logger(logging.ComponentOutbox)
+
+const outboxRecordExt = ".rec"
+
+// outboxRecord - формат сообщения, сохраняемого на диск.
+// Вместе с сериализованным сообщением сохраняется JSON исходной структуры: при повторной отправке
+// он становится Payload сообщения для синков, которые строят запись из полей (CSV архива).
+type outboxRecord struct {
+	Message   entities.Message `json:"message"`
+	Payload   json.RawMessage  `json:"payload,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// outboxEntry - запись индекса очереди в памяти
+type outboxEntry struct {
+	seq       uint64
+	size      int64
+	createdAt time.Time
+}
+
+// Outbox - дисковая очередь (write-ahead outbox) перед продюсером.
+// Если отправка не удалась или очередь уже не пуста, сообщение сохраняется на диск,
+// а фоновый обработчик отправляет сохраненные сообщения по порядку после восстановления брокера.
+type Outbox struct {
+	name          string
+	dir           string
+	maxBytes      int64
+	retryInterval time.Duration
+	inner         interfaces.DataProducer
+
+	mu        sync.Mutex
+	entries   []outboxEntry
+	nextSeq   uint64
+	bytes     int64
+	dropped   uint64
+	lastError string
+
+	wake chan struct{}
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewOutbox создает очередь в каталоге dir, восстанавливает ранее сохраненные сообщения
+// и запускает фоновую отправку
+func NewOutbox(name, dir string, maxBytes int64, retryInterval time.Duration, inner interfaces.DataProducer) (*Outbox, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("не удалось создать каталог outbox %s: %w", dir, err)
+	}
+	o := &Outbox{
+		name:          name,
+		dir:           dir,
+		maxBytes:      maxBytes,
+		retryInterval: retryInterval,
+		inner:         inner,
+		wake:          make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+	}
+	if err := o.load(); err != nil {
+		return nil, err
+	}
+	if len(o.entries) > 0 {
+		outboxLog.Info("восстановлены неотправленные сообщения", "outbox", name, "messages", len(o.entries), "bytes", o.bytes)
+	}
+
+	o.wg.Add(1)
+	go o.drainLoop()
+	o.signal()
+	return o, nil
+}
+
+// Produce отправляет сообщение напрямую, если очередь пуста, иначе (или при ошибке) сохраняет его на диск.
+// Ошибка возвращается только если сообщение не удалось ни отправить, ни сохранить.
+func (o *Outbox) Produce(ctx context.Context, msg *entities.Message) error {
+	if o.Depth() == 0 {
+		err := o.inner.Produce(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		o.setLastError(err)
+	}
+	return o.Persist(msg)
+}
+
+// Persist сохраняет сообщение в конец очереди
+func (o *Outbox) Persist(msg *entities.Message) error {
+	record := outboxRecord{Message: *msg, CreatedAt: time.Now()}
+	if msg.Payload != nil {
+		payload, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return fmt.Errorf("не удалось сериализовать сообщение outbox: %w", err)
+		}
+		record.Payload = payload
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать сообщение outbox: %w", err)
+	}
+	size := int64(len(data))
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.maxBytes > 0 && size > o.maxBytes {
+		o.dropped++
+		return fmt.Errorf("сообщение размером %d байт превышает лимит outbox '%s'", size, o.name)
+	}
+	// При превышении лимита вытесняются самые старые сообщения
+	for o.maxBytes > 0 && o.bytes+size > o.maxBytes && len(o.entries) > 0 {
+		oldest := o.entries[0]
+		if err := os.Remove(o.recordPath(oldest.seq)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("не удалось освободить место в outbox '%s': %w", o.name, err)
+		}
+		o.entries = o.entries[1:]
+		o.bytes -= oldest.size
+		o.dropped++
+		outboxLog.Warn("очередь переполнена, удалено старейшее сообщение", "outbox", o.name, "seq", oldest.seq)
+	}
+
+	seq := o.nextSeq
+	path := o.recordPath(seq)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось записать сообщение в outbox '%s': %w", o.name, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("не удалось записать сообщение в outbox '%s': %w", o.name, err)
+	}
+	o.nextSeq++
+	o.entries = append(o.entries, outboxEntry{seq: seq, size: size, createdAt: record.CreatedAt})
+	o.bytes += size
+	o.signal()
+	return nil
+}
+
+// Depth возвращает количество сообщений в очереди
+func (o *Outbox) Depth() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.entries)
+}
+
+// Stats возвращает состояние очереди для мониторинга
+func (o *Outbox) Stats() entities.OutboxStats {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	stats := entities.OutboxStats{
+		Name:      o.name,
+		Depth:     len(o.entries),
+		Bytes:     o.bytes,
+		MaxBytes:  o.maxBytes,
+		Dropped:   o.dropped,
+		LastError: o.lastError,
+	}
+	if len(o.entries) > 0 {
+		stats.OldestAgeSec = time.Since(o.entries[0].createdAt).Seconds()
+	}
+	return stats
+}
+
+// SinkDetails передает подробное состояние синка, обернутого очередью
+func (o *Outbox) SinkDetails() interface{} {
+	if provider, ok := o.inner.(interfaces.SinkDetailsProvider); ok {
+		return provider.SinkDetails()
+	}
+	return nil
+}
+
+// Close останавливает фоновую отправку и закрывает продюсер. Неотправленные сообщения остаются на диске.
+func (o *Outbox) Close() error {
+	close(o.stop)
+	o.wg.Wait()
+	if depth := o.Depth(); depth > 0 {
+		outboxLog.Info("сообщения будут отправлены после перезапуска", "outbox", o.name, "messages", depth)
+	}
+	return o.inner.Close()
+}
+
+func (o *Outbox) signal() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Outbox) drainLoop() {
+	defer o.wg.Done()
+	ticker := time.NewTicker(o.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-o.stop:
+			return
+		case <-o.wake:
+		case <-ticker.C:
+		}
+		o.drain()
+	}
+}
+
+// drain отправляет сообщения по порядку до первой ошибки
+func (o *Outbox) drain() {
+	for {
+		select {
+		case <-o.stop:
+			return
+		default:
+		}
+
+		o.mu.Lock()
+		if len(o.entries) == 0 {
+			o.mu.Unlock()
+			return
+		}
+		head := o.entries[0]
+		o.mu.Unlock()
+
+		record, err := o.readRecord(head.seq)
+		if err != nil {
+			outboxLog.Error("поврежденное сообщение удалено", "outbox", o.name, "seq", head.seq, logging.Err(err))
+			o.removeHead(head.seq)
+			continue
+		}
+
+		targets := record.Message.Targets
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err = o.inner.Produce(ctx, &record.Message)
+		cancel()
+		if err != nil {
+			o.setLastError(err)
+			// Часть получателей могла принять сообщение: запись обновляется, чтобы не отправлять его им повторно
+			if !slices.Equal(targets, record.Message.Targets) {
+				o.rewriteHead(head.seq, record)
+			}
+			return
+		}
+		o.removeHead(head.seq)
+	}
+}
+
+// rewriteHead сохраняет измененную головную запись на место прежней
+func (o *Outbox) rewriteHead(seq uint64, record outboxRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		outboxLog.Error("не удалось сериализовать сообщение outbox", "outbox", o.name, "seq", seq, logging.Err(err))
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.entries) == 0 || o.entries[0].seq != seq {
+		return
+	}
+	path := o.recordPath(seq)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		outboxLog.Error("не удалось обновить сообщение outbox", "outbox", o.name, "seq", seq, logging.Err(err))
+		return
+	}
+	o.bytes += int64(len(data)) - o.entries[0].size
+	o.entries[0].size = int64(len(data))
+}
+
+func (o *Outbox) removeHead(seq uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// Головная запись могла быть вытеснена при переполнении, пока шла отправка
+	if len(o.entries) == 0 || o.entries[0].seq != seq {
+		return
+	}
+	if err := os.Remove(o.recordPath(seq)); err != nil && !os.IsNotExist(err) {
+		outboxLog.Error("не удалось удалить отправленное сообщение", "outbox", o.name, "seq", seq, logging.Err(err))
+	}
+	o.bytes -= o.entries[0].size
+	o.entries = o.entries[1:]
+	if len(o.entries) == 0 {
+		o.lastError = ""
+	}
+}
+
+func (o *Outbox) setLastError(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.lastError = err.Error()
+}
+
+func (o *Outbox) readRecord(seq uint64) (outboxRecord, error) {
+	var record outboxRecord
+	data, err := os.ReadFile(o.recordPath(seq))
+	if err != nil {
+		return record, err
+	}
+	if err = json.Unmarshal(data, &record); err != nil {
+		return record, err
+	}
+	if record.Payload != nil {
+		record.Message.Payload = record.Payload
+	}
+	return record, nil
+}
+
+// load восстанавливает индекс очереди из файлов каталога
+func (o *Outbox) load() error {
+	files, err := os.ReadDir(o.dir)
+	if err != nil {
+		return fmt.Errorf("не удалось прочитать каталог outbox %s: %w", o.dir, err)
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(name, ".tmp") {
+			_ = os.Remove(filepath.Join(o.dir, name))
+			continue
+		}
+		if !strings.HasSuffix(name, outboxRecordExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, outboxRecordExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return err
+		}
+		o.entries = append(o.entries, outboxEntry{seq: seq, size: info.Size(), createdAt: info.ModTime()})
+		o.bytes += info.Size()
+	}
+	sort.Slice(o.entries, func(i, j int) bool { return o.entries[i].seq < o.entries[j].seq })
+	if len(o.entries) > 0 {
+		o.nextSeq = o.entries[len(o.entries)-1].seq + 1
+	}
+	return nil
+}
+
+func (o *Outbox) recordPath(seq uint64) string {
+	return filepath.Join(o.dir, fmt.Sprintf("%020d%s", seq, outboxRecordExt))
+}
+
+// OutboxRegistry собирает все очереди приложения для мониторинга
+type OutboxRegistry struct {
+	mu       sync.RWMutex
+	outboxes []*Outbox
+}
+
+func NewOutboxRegistry() *OutboxRegistry {
+	return &OutboxRegistry{}
+}
+
+// NewOutboxMonitor предоставляет реестр очередей как интерфейс мониторинга
+func NewOutboxMonitor(registry *OutboxRegistry) interfaces.OutboxMonitor {
+	return registry
+}
+
+func (r *OutboxRegistry) Register(outbox *Outbox) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outboxes = append(r.outboxes, outbox)
+}
+
+// OutboxStats возвращает состояние всех зарегистрированных очередей
+func (r *OutboxRegistry) OutboxStats() []entities.OutboxStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stats := make([]entities.OutboxStats, 0, len(r.outboxes))
+	for _, outbox := range r.outboxes {
+		stats = append(stats, outbox.Stats())
+	}
+	return stats
+}
@@ -0,0 +1,77 @@
+package producers
+
+import (
+	"MTConnect/internal/domain/entities"
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// switchableProducer отклоняет сообщения, пока не включен, и запоминает принятые
+type switchableProducer struct {
+	mu       sync.Mutex
+	enabled  bool
+	messages []*entities.Message
+}
+
+func (p *switchableProducer) Produce(_ context.Context, msg *entities.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.enabled {
+		return errors.New("брокер недоступен")
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func (p *switchableProducer) Close() error { return nil }
+
+func (p *switchableProducer) enable() {
+	p.mu.Lock()
+	p.enabled = true
+	p.mu.Unlock()
+}
+
+func (p *switchableProducer) received() []*entities.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*entities.Message(nil), p.messages...)
+}
+
+func TestOutboxReplayRestoresPayload(t *testing.T) {
+	dir := t.TempDir()
+	inner := &switchableProducer{}
+	data := testMachineData()
+	msg := &entities.Message{ID: "m1", Type: entities.MessageTypeSnapshot, MachineID: "Mazak", Payload: data, Value: []byte(`{}`)}
+
+	outbox, err := NewOutbox("kafka", dir, 0, 10*time.Millisecond, inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := outbox.Produce(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := outbox.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// После перезапуска сообщение читается с диска и отправляется с восстановленным Payload
+	inner.enable()
+	outbox, err = NewOutbox("kafka", dir, 0, 10*time.Millisecond, inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outbox.Close()
+	waitForCondition(t, "повторная отправка из outbox", func() bool { return len(inner.received()) == 1 })
+
+	replayed := inner.received()[0]
+	if replayed.ID != msg.ID || string(replayed.Value) != string(msg.Value) {
+		t.Errorf("повторно отправлено другое сообщение: %+v", replayed)
+	}
+	if got, want := entities.FlattenValue(replayed.Payload), entities.FlattenValue(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("поля восстановленного Payload:\n%v\nожидались:\n%v", got, want)
+	}
+}
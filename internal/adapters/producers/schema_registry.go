@@ -0,0 +1,135 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	schemaTypeAvro     = "AVRO"
+	schemaTypeProtobuf = "PROTOBUF"
+
+	schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+	schemaRegistryTimeout     = 10 * time.Second
+)
+
+// schemaRegistry - клиент Confluent Schema Registry. Идентификаторы схем
+// запрашиваются один раз для каждого subject и кэшируются.
+type schemaRegistry struct {
+	baseURL      string
+	username     string
+	password     string
+	autoRegister bool
+	client       *http.Client
+
+	mu  sync.Mutex
+	ids map[string]uint32
+}
+
+func newSchemaRegistry(cfg *config.SchemaRegistryConfig) (*schemaRegistry, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("не задан url schema registry")
+	}
+	client := &http.Client{Timeout: schemaRegistryTimeout}
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	return &schemaRegistry{
+		baseURL:      strings.TrimSuffix(cfg.URL, "/"),
+		username:     cfg.Username,
+		password:     cfg.Password,
+		autoRegister: *cfg.AutoRegister,
+		client:       client,
+		ids:          make(map[string]uint32),
+	}, nil
+}
+
+// schemaID возвращает идентификатор схемы subject, регистрируя ее при необходимости
+func (r *schemaRegistry) schemaID(subject, schemaType, schema string) (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.ids[subject]; ok {
+		return id, nil
+	}
+
+	// Регистрация существующей схемы идемпотентна и возвращает ее текущий идентификатор
+	path := "/subjects/" + url.PathEscape(subject)
+	if r.autoRegister {
+		path += "/versions"
+	}
+	request := map[string]string{"schema": schema}
+	if schemaType != schemaTypeAvro {
+		request["schemaType"] = schemaType
+	}
+	var response struct {
+		ID uint32 `json:"id"`
+	}
+	if err := r.post(path, request, &response); err != nil {
+		return 0, fmt.Errorf("не удалось получить идентификатор схемы '%s': %w", subject, err)
+	}
+	r.ids[subject] = response.ID
+	return response.ID, nil
+}
+
+func (r *schemaRegistry) post(path string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), schemaRegistryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", schemaRegistryContentType)
+	req.Header.Set("Accept", schemaRegistryContentType)
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("schema registry ответил со статусом %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return json.Unmarshal(respBody, response)
+}
+
+// confluentHeader формирует заголовок формата Confluent: нулевой magic byte и идентификатор схемы.
+// Для Protobuf за ним следуют индексы сообщения в файле схемы (zigzag varint),
+// где первое сообщение файла кодируется одним нулевым байтом.
+func confluentHeader(schemaID uint32, protoMessageIndex int) []byte {
+	header := make([]byte, 5, 8)
+	binary.BigEndian.PutUint32(header[1:], schemaID)
+	switch {
+	case protoMessageIndex < 0:
+	case protoMessageIndex == 0:
+		header = append(header, 0)
+	default:
+		header = binary.AppendVarint(header, 1)
+		header = binary.AppendVarint(header, int64(protoMessageIndex))
+	}
+	return header
+}
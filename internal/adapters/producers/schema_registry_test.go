@@ -0,0 +1,290 @@
+package producers
+
+import (
+	mtconnectv1 "MTConnect/api/mtconnect/v1"
+	"MTConnect/internal/adapters/schema"
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// registryRequest - запрос к mock schema registry
+type registryRequest struct {
+	path        string
+	contentType string
+	username    string
+	body        map[string]string
+}
+
+// mockSchemaRegistry отвечает на запросы регистрации и поиска схем идентификатором id
+type mockSchemaRegistry struct {
+	id uint32
+
+	mu       sync.Mutex
+	requests []registryRequest
+}
+
+func (m *mockSchemaRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	username, _, _ := r.BasicAuth()
+	m.mu.Lock()
+	m.requests = append(m.requests, registryRequest{path: r.URL.Path, contentType: r.Header.Get("Content-Type"), username: username, body: body})
+	m.mu.Unlock()
+	w.Header().Set("Content-Type", schemaRegistryContentType)
+	_ = json.NewEncoder(w).Encode(map[string]uint32{"id": m.id})
+}
+
+func (m *mockSchemaRegistry) calls() []registryRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]registryRequest(nil), m.requests...)
+}
+
+func newRegistryEncoder(t *testing.T, encoding string, registry *mockSchemaRegistry, autoRegister bool) Encoder {
+	t.Helper()
+	server := httptest.NewServer(registry)
+	t.Cleanup(server.Close)
+	encoder, err := newEncoder(config.SinkConfig{
+		Name:     "kafka",
+		Encoding: encoding,
+		SchemaRegistry: &config.SchemaRegistryConfig{
+			URL:          server.URL + "/",
+			Username:     "registry-user",
+			Password:     "secret",
+			AutoRegister: &autoRegister,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoder
+}
+
+func testMachineData() entities.MachineData {
+	return entities.MachineData{
+		MachineId:    "Mazak",
+		Id:           "d1",
+		Timestamp:    "2024-01-01T00:00:00Z",
+		IsEnabled:    true,
+		MachineState: "ACTIVE",
+		FeedRate:     map[string]string{"VALUE": "100.5"},
+		PartsCount:   map[string]string{"VALUE": "42"},
+		AxisInfos: []entities.AxisInfo{
+			{ID: "x", Name: "X", Type: "Linear", Data: map[string]interface{}{"position": "12.5"}},
+		},
+		Conditions: []entities.ConditionState{
+			{DataItemId: "sys", Type: "SYSTEM", State: "FAULT", Activations: []entities.ConditionActivation{
+				{NativeCode: "E1", Level: "FAULT", Message: "Overheat"},
+			}},
+		},
+	}
+}
+
+// splitConfluentHeader проверяет magic byte и возвращает идентификатор схемы и остаток сообщения
+func splitConfluentHeader(t *testing.T, value []byte) (uint32, []byte) {
+	t.Helper()
+	if len(value) < 5 {
+		t.Fatalf("сообщение короче заголовка Confluent: %d байт", len(value))
+	}
+	if value[0] != 0 {
+		t.Fatalf("magic byte = %d, ожидался 0", value[0])
+	}
+	return binary.BigEndian.Uint32(value[1:5]), value[5:]
+}
+
+// readMessageIndexes читает индексы сообщения Protobuf в файле схемы (zigzag varint)
+func readMessageIndexes(t *testing.T, data []byte) ([]int64, []byte) {
+	t.Helper()
+	count, n := binary.Varint(data)
+	if n <= 0 {
+		t.Fatal("не удалось прочитать число индексов сообщения")
+	}
+	data = data[n:]
+	if count == 0 {
+		// Первое сообщение файла кодируется одним нулевым байтом
+		return []int64{0}, data
+	}
+	indexes := make([]int64, 0, count)
+	for i := int64(0); i < count; i++ {
+		index, n := binary.Varint(data)
+		if n <= 0 {
+			t.Fatal("не удалось прочитать индекс сообщения")
+		}
+		indexes = append(indexes, index)
+		data = data[n:]
+	}
+	return indexes, data
+}
+
+func TestConfluentHeader(t *testing.T) {
+	tests := []struct {
+		name         string
+		messageIndex int
+		want         []byte
+	}{
+		{"avro", -1, []byte{0, 0, 0, 1, 0x2c}},
+		{"protobuf first message", 0, []byte{0, 0, 0, 1, 0x2c, 0}},
+		{"protobuf message 8", 8, []byte{0, 0, 0, 1, 0x2c, 0x02, 0x10}},
+	}
+	for _, test := range tests {
+		if got := confluentHeader(300, test.messageIndex); !bytes.Equal(got, test.want) {
+			t.Errorf("%s: confluentHeader = %x, ожидалось %x", test.name, got, test.want)
+		}
+	}
+}
+
+func TestProtobufEncoderSchemaRegistry(t *testing.T) {
+	registry := &mockSchemaRegistry{id: 42}
+	encoder := newRegistryEncoder(t, "protobuf", registry, true)
+	data := testMachineData()
+
+	for i := 0; i < 2; i++ {
+		value, contentType, err := encoder.Encode(&entities.Message{Type: entities.MessageTypeSnapshot, Payload: data})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contentType != contentTypeProtobuf {
+			t.Errorf("content type = %s", contentType)
+		}
+		schemaID, rest := splitConfluentHeader(t, value)
+		if schemaID != 42 {
+			t.Errorf("идентификатор схемы = %d, ожидался 42", schemaID)
+		}
+		indexes, rest := readMessageIndexes(t, rest)
+		if len(indexes) != 1 || indexes[0] != 0 {
+			t.Errorf("индексы сообщения = %v, ожидался [0] (MachineData - первое сообщение файла)", indexes)
+		}
+
+		var decoded mtconnectv1.MachineData
+		if err := proto.Unmarshal(rest, &decoded); err != nil {
+			t.Fatalf("сообщение не разбирается как mtconnect.v1.MachineData: %v", err)
+		}
+		expected, err := schema.ToMessage(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(&decoded, expected) {
+			t.Errorf("разобранное сообщение отличается от исходного:\n%v", &decoded)
+		}
+		if decoded.GetMachineId() != "Mazak" || !decoded.GetIsEnabled() || decoded.GetFeedRate()["VALUE"] != "100.5" ||
+			decoded.GetAxisInfos()[0].GetData()["position"] != "12.5" || decoded.GetConditions()[0].GetActivations()[0].GetNativeCode() != "E1" {
+			t.Errorf("поля снимка потеряны при сериализации: %v", &decoded)
+		}
+	}
+
+	// Идентификатор схемы запрашивается один раз и затем берется из кэша
+	calls := registry.calls()
+	if len(calls) != 1 {
+		t.Fatalf("запросов к schema registry: %d, ожидался 1", len(calls))
+	}
+	call := calls[0]
+	if call.path != "/subjects/mtconnect.v1.MachineData/versions" {
+		t.Errorf("путь регистрации = %s", call.path)
+	}
+	if call.contentType != schemaRegistryContentType || call.username != "registry-user" {
+		t.Errorf("content type = %s, пользователь = %s", call.contentType, call.username)
+	}
+	if call.body["schemaType"] != schemaTypeProtobuf || call.body["schema"] != mtconnectv1.ProtoSchema {
+		t.Errorf("тело регистрации: schemaType = %s, схема совпадает с mtconnect.proto: %v",
+			call.body["schemaType"], call.body["schema"] == mtconnectv1.ProtoSchema)
+	}
+
+	// Для других сообщений файла регистрируется свой subject, а заголовок содержит их индекс
+	end := time.UnixMilli(1700000060000)
+	event := entities.AlarmEvent{EventType: "CLEARED", MachineId: "Mazak", NativeCode: "E1", StartTime: time.UnixMilli(1700000000000), EndTime: &end, DurationMs: 60000}
+	value, _, err := encoder.Encode(&entities.Message{Type: entities.MessageTypeAlarm, Payload: event})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, rest := splitConfluentHeader(t, value)
+	indexes, rest := readMessageIndexes(t, rest)
+	wantIndex := int64((&mtconnectv1.AlarmEvent{}).ProtoReflect().Descriptor().Index())
+	if len(indexes) != 1 || indexes[0] != wantIndex {
+		t.Errorf("индексы AlarmEvent = %v, ожидался [%d]", indexes, wantIndex)
+	}
+	var decodedEvent mtconnectv1.AlarmEvent
+	if err := proto.Unmarshal(rest, &decodedEvent); err != nil {
+		t.Fatal(err)
+	}
+	if decodedEvent.GetNativeCode() != "E1" || decodedEvent.GetEndTimeMs() != end.UnixMilli() || decodedEvent.GetDurationMs() != 60000 {
+		t.Errorf("событие аварии потеряно при сериализации: %v", &decodedEvent)
+	}
+	if calls := registry.calls(); len(calls) != 2 || calls[1].path != "/subjects/mtconnect.v1.AlarmEvent/versions" {
+		t.Errorf("запросы к schema registry: %+v", calls)
+	}
+}
+
+func TestAvroEncoderSchemaRegistry(t *testing.T) {
+	registry := &mockSchemaRegistry{id: 7}
+	encoder := newRegistryEncoder(t, "avro", registry, false)
+	data := testMachineData()
+
+	value, contentType, err := encoder.Encode(&entities.Message{Type: entities.MessageTypeSnapshot, Payload: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != contentTypeAvro {
+		t.Errorf("content type = %s", contentType)
+	}
+	// Для Avro за идентификатором схемы сразу следуют данные, без индексов сообщения
+	schemaID, rest := splitConfluentHeader(t, value)
+	if schemaID != 7 {
+		t.Errorf("идентификатор схемы = %d, ожидался 7", schemaID)
+	}
+
+	schema, err := mtconnectv1.AvroSchema("MachineData")
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	native, remaining, err := codec.NativeFromBinary(rest)
+	if err != nil {
+		t.Fatalf("сообщение не разбирается по схеме MachineData.avsc: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("после записи остались лишние байты: %d", len(remaining))
+	}
+	record := native.(map[string]interface{})
+	if record["machine_id"] != "Mazak" || record["machine_state"] != "ACTIVE" {
+		t.Errorf("поля записи: machine_id = %v, machine_state = %v", record["machine_id"], record["machine_state"])
+	}
+	if isEnabled, _ := record["is_enabled"].(map[string]interface{}); isEnabled["boolean"] != true {
+		t.Errorf("is_enabled = %v, ожидалось объединение boolean true", record["is_enabled"])
+	}
+	if feedRate, _ := record["feed_rate"].(map[string]interface{}); feedRate["VALUE"] != "100.5" {
+		t.Errorf("feed_rate = %v", record["feed_rate"])
+	}
+	axes, _ := record["axis_infos"].([]interface{})
+	if len(axes) != 1 || axes[0].(map[string]interface{})["name"] != "X" {
+		t.Errorf("axis_infos = %v", record["axis_infos"])
+	}
+
+	// Без auto_register идентификатор ищется среди зарегистрированных схем subject
+	calls := registry.calls()
+	if len(calls) != 1 {
+		t.Fatalf("запросов к schema registry: %d, ожидался 1", len(calls))
+	}
+	if calls[0].path != "/subjects/mtconnect.v1.MachineData" {
+		t.Errorf("путь поиска схемы = %s", calls[0].path)
+	}
+	if _, ok := calls[0].body["schemaType"]; ok || calls[0].body["schema"] != schema {
+		t.Errorf("тело запроса Avro: %v", calls[0].body)
+	}
+}
@@ -0,0 +1,109 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"encoding/json"
+	"strings"
+)
+
+// alwaysIncludedFields - поля снимка, которые сохраняются при любом фильтре полей
+var alwaysIncludedFields = []string{"MachineId", "Id", "Timestamp"}
+
+// sinkFilter отбирает сообщения по станкам и типам и сокращает снимки до выбранных полей
+type sinkFilter struct {
+	machines     map[string]bool
+	messageTypes map[string]bool
+	fields       map[string]bool
+	// routes - типы сообщений, которые продюсер синка умеет публиковать; nil - все типы
+	routes func(messageType string) bool
+}
+
+func newSinkFilter(cfg config.SinkFilter) sinkFilter {
+	return sinkFilter{
+		machines:     toSet(cfg.Machines),
+		messageTypes: toSet(cfg.MessageTypes),
+		fields:       toSet(cfg.Fields),
+	}
+}
+
+func (f sinkFilter) matches(msg *entities.Message) bool {
+	if len(f.machines) > 0 && !f.machines[msg.MachineID] {
+		return false
+	}
+	if len(f.messageTypes) > 0 && !f.messageTypes[msg.Type] {
+		return false
+	}
+	if f.routes != nil && !f.routes(msg.Type) {
+		return false
+	}
+	return true
+}
+
+// project возвращает полезную нагрузку, ограниченную выбранными полями.
+// Второе значение false означает, что после фильтрации сообщение не содержит данных.
+func (f sinkFilter) project(msg *entities.Message) (interface{}, bool) {
+	if len(f.fields) == 0 {
+		return msg.Payload, true
+	}
+	switch payload := msg.Payload.(type) {
+	case entities.MachineData:
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return msg.Payload, true
+		}
+		var document map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &document); err != nil {
+			return msg.Payload, true
+		}
+		for field := range document {
+			if !f.includesPath(field) {
+				delete(document, field)
+			}
+		}
+		return document, true
+	case entities.MachineDataDelta:
+		projected := payload
+		projected.Changed = make(map[string]interface{})
+		for path, value := range payload.Changed {
+			if f.includesPath(path) {
+				projected.Changed[path] = value
+			}
+		}
+		projected.Removed = nil
+		for _, path := range payload.Removed {
+			if f.includesPath(path) {
+				projected.Removed = append(projected.Removed, path)
+			}
+		}
+		return projected, len(projected.Changed) > 0 || len(projected.Removed) > 0
+	}
+	return msg.Payload, true
+}
+
+func (f sinkFilter) includesPath(path string) bool {
+	top := path
+	if i := strings.IndexByte(path, '.'); i >= 0 {
+		top = path[:i]
+	}
+	if f.fields[top] {
+		return true
+	}
+	for _, field := range alwaysIncludedFields {
+		if top == field {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,396 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var sinksLog = logging.Logger(logging.ComponentSinks)
+
+// SinkFactory создает продюсер для синка заданного типа
+type SinkFactory func(sink config.SinkConfig, cfg *config.AppConfig) (interfaces.DataProducer, error)
+
+// sinkFactories - поддерживаемые типы синков
+var sinkFactories = map[string]SinkFactory{
+	config.SinkTypeKafka:   NewKafkaSink,
+	config.SinkTypeMQTT:    NewMQTTSink,
+	config.SinkTypeWebhook: NewWebhookSink,
+	config.SinkTypeFile:    NewFileSink,
+}
+
+const sinkDeliveryTimeout = 30 * time.Second
+
+// tracer возвращает трассировщик спанов отправки сообщений синками из текущего глобального провайдера
+func tracer() trace.Tracer {
+	return otel.Tracer("MTConnect/internal/adapters/producers")
+}
+
+// SinkRegistry рассылает сообщения во все настроенные синки.
+// Каждый синк работает в своей горутине с собственной очередью, фильтром и кодировщиком,
+// поэтому медленный или недоступный синк не блокирует цикл опроса и остальные синки.
+type SinkRegistry struct {
+	sinks []*sinkWorker
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewSinkRegistry создает синки из конфигурации и запускает их обработчики
+func NewSinkRegistry(cfg *config.AppConfig, outboxes *OutboxRegistry, metrics interfaces.Metrics) (*SinkRegistry, error) {
+	registry := &SinkRegistry{}
+	for _, sinkCfg := range cfg.Sinks {
+		if !sinkCfg.IsEnabled() {
+			continue
+		}
+		worker, err := newSinkWorker(sinkCfg, cfg, outboxes, metrics)
+		if err != nil {
+			_ = registry.Close()
+			return nil, fmt.Errorf("не удалось создать синк '%s': %w", sinkCfg.Name, err)
+		}
+		registry.sinks = append(registry.sinks, worker)
+		sinksLog.Info("синк запущен", "sink", sinkCfg.Name, "type", sinkCfg.Type)
+	}
+	return registry, nil
+}
+
+// NewDataProducer предоставляет реестр синков как единый продюсер
+func NewDataProducer(registry *SinkRegistry) interfaces.DataProducer {
+	return registry
+}
+
+// NewSinkMonitor предоставляет реестр синков как интерфейс мониторинга
+func NewSinkMonitor(registry *SinkRegistry) interfaces.SinkMonitor {
+	return registry
+}
+
+// Produce ставит сообщение в очереди синков, фильтр которых его пропускает. Вызов не блокируется.
+func (r *SinkRegistry) Produce(ctx context.Context, msg *entities.Message) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return fmt.Errorf("синки публикации уже закрыты")
+	}
+	for _, sink := range r.sinks {
+		sink.enqueue(msg)
+	}
+	return nil
+}
+
+// Close дожидается отправки сообщений из очередей и закрывает все синки
+func (r *SinkRegistry) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	r.mu.Unlock()
+
+	var errs []string
+	for _, sink := range r.sinks {
+		if err := sink.close(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", sink.name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("ошибки при закрытии синков: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// SinkStats возвращает состояние всех синков
+func (r *SinkRegistry) SinkStats() []entities.SinkStats {
+	stats := make([]entities.SinkStats, 0, len(r.sinks))
+	for _, sink := range r.sinks {
+		stats = append(stats, sink.stats())
+	}
+	return stats
+}
+
+// CheckSinks проверяет связь с внешними системами синков, поддерживающих проверку.
+// Синки проверяются параллельно, время каждой проверки ограничено контекстом.
+func (r *SinkRegistry) CheckSinks(ctx context.Context) []entities.SinkCheck {
+	// Емкость задана заранее: append не перераспределяет массив, на элементы которого ссылаются горутины
+	checks := make([]entities.SinkCheck, 0, len(r.sinks))
+	var wg sync.WaitGroup
+	for _, sink := range r.sinks {
+		checker, buffered, ok := sinkHealthChecker(sink.producer)
+		if !ok {
+			continue
+		}
+		checks = append(checks, entities.SinkCheck{Name: sink.name, Type: sink.sinkType, Status: entities.HealthStatusOK, Buffered: buffered})
+		wg.Add(1)
+		go func(check *entities.SinkCheck) {
+			defer wg.Done()
+			if err := checker.CheckHealth(ctx); err != nil {
+				check.Status = entities.HealthStatusFail
+				check.Error = err.Error()
+			}
+		}(&checks[len(checks)-1])
+	}
+	wg.Wait()
+	return checks
+}
+
+// sinkHealthChecker возвращает проверку связи синка; для дисковой очереди проверяется обернутый ею синк
+func sinkHealthChecker(producer interfaces.DataProducer) (checker interfaces.SinkHealthChecker, buffered, ok bool) {
+	if outbox, isOutbox := producer.(*Outbox); isOutbox {
+		producer, buffered = outbox.inner, true
+	}
+	checker, ok = producer.(interfaces.SinkHealthChecker)
+	return checker, buffered, ok
+}
+
+// sinkWorker - синк с очередью, фильтром и кодировщиком
+type sinkWorker struct {
+	name        string
+	sinkType    string
+	filter      sinkFilter
+	encoder     Encoder
+	cloudEvents *cloudEventsFormatter
+	producer    interfaces.DataProducer
+	metrics     interfaces.Metrics
+	queue       chan *entities.Message
+	done        chan struct{}
+	// deferred - доставка подтверждается продюсером позже возврата из Produce
+	deferred bool
+
+	delivered atomic.Uint64
+	failed    atomic.Uint64
+	dropped   atomic.Uint64
+
+	mu           sync.Mutex
+	lastError    string
+	lastDelivery time.Time
+	lastDropLog  time.Time
+}
+
+func newSinkWorker(sinkCfg config.SinkConfig, cfg *config.AppConfig, outboxes *OutboxRegistry, metrics interfaces.Metrics) (*sinkWorker, error) {
+	factory, ok := sinkFactories[sinkCfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("неизвестный тип синка '%s'", sinkCfg.Type)
+	}
+	encoder, err := newEncoder(sinkCfg)
+	if err != nil {
+		return nil, err
+	}
+	cloudEvents, err := newCloudEventsFormatter(sinkCfg.CloudEvents, sinkCfg.Type)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := factory(sinkCfg, cfg)
+	if err != nil {
+		return nil, err
+	}
+	var outbox *Outbox
+	if sinkCfg.Outbox != nil && *sinkCfg.Outbox {
+		outbox, err = NewOutbox(
+			sinkCfg.Name,
+			filepath.Join(cfg.Outbox.Dir, sinkCfg.Name),
+			cfg.Outbox.MaxBytes,
+			time.Duration(cfg.Outbox.RetryIntervalMs)*time.Millisecond,
+			producer,
+		)
+		if err != nil {
+			_ = producer.Close()
+			return nil, err
+		}
+		outboxes.Register(outbox)
+	}
+
+	worker := &sinkWorker{
+		name:        sinkCfg.Name,
+		sinkType:    sinkCfg.Type,
+		filter:      newSinkFilter(sinkCfg.Filter),
+		encoder:     encoder,
+		cloudEvents: cloudEvents,
+		producer:    producer,
+		metrics:     metrics,
+		queue:       make(chan *entities.Message, sinkCfg.QueueSize),
+		done:        make(chan struct{}),
+	}
+	if routed, ok := producer.(routedProducer); ok {
+		worker.filter.routes = routed.Routes
+	}
+	// Отложенная доставка учитывается по подтверждению продюсера; недоставленные сообщения
+	// сохраняются в outbox, а без него учитываются синком как неудачные
+	if async, ok := producer.(asyncProducer); ok && async.Async() {
+		worker.deferred = true
+		async.SetDeliveryHandler(worker.recordDelivered)
+		if outbox != nil {
+			async.SetFallback(outbox.Persist)
+		} else {
+			async.SetFallback(worker.recordLost)
+		}
+	}
+	if outbox != nil {
+		worker.producer = outbox
+	}
+	go worker.run()
+	return worker, nil
+}
+
+// routedProducer реализуется продюсерами, которые публикуют только часть типов сообщений (MQTT)
+type routedProducer interface {
+	Routes(messageType string) bool
+}
+
+// asyncProducer реализуется продюсерами, которые могут завершать доставку после возврата из Produce
+// (асинхронный режим Kafka, пакеты webhook)
+type asyncProducer interface {
+	// Async сообщает, подтверждается ли доставка позже возврата из Produce
+	Async() bool
+	// SetFallback задает обработчик сообщений, доставить которые не удалось
+	SetFallback(func(msg *entities.Message) error)
+	// SetDeliveryHandler задает обработчик подтверждения доставки count сообщений
+	SetDeliveryHandler(func(count int))
+}
+
+func (w *sinkWorker) enqueue(msg *entities.Message) {
+	if !w.filter.matches(msg) {
+		return
+	}
+	select {
+	case w.queue <- msg:
+	default:
+		w.dropped.Add(1)
+		w.mu.Lock()
+		shouldLog := time.Since(w.lastDropLog) > 10*time.Second
+		if shouldLog {
+			w.lastDropLog = time.Now()
+		}
+		w.mu.Unlock()
+		if shouldLog {
+			sinksLog.Warn("очередь синка переполнена, сообщения отбрасываются", "sink", w.name, "dropped", w.dropped.Load())
+		}
+	}
+}
+
+func (w *sinkWorker) run() {
+	defer close(w.done)
+	for msg := range w.queue {
+		w.deliver(msg)
+	}
+}
+
+func (w *sinkWorker) deliver(source *entities.Message) {
+	msg := source.Clone()
+	payload, ok := w.filter.project(msg)
+	if !ok {
+		return
+	}
+	msg.Payload = payload
+
+	value, contentType, err := w.encoder.Encode(msg)
+	if err != nil {
+		w.recordFailure(fmt.Errorf("ошибка сериализации: %w", err))
+		return
+	}
+	msg.Value, msg.ContentType = value, contentType
+	if w.cloudEvents != nil {
+		if err := w.cloudEvents.apply(msg); err != nil {
+			w.recordFailure(err)
+			return
+		}
+	}
+
+	// Спан отправки продолжает трассу из заголовков сообщения и заменяет их собой,
+	// чтобы потребитель связал сообщение с конкретной отправкой
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(source.Headers))
+	ctx, span := tracer().Start(ctx, "Produce", trace.WithSpanKind(trace.SpanKindProducer), trace.WithAttributes(
+		attribute.String("mtconnect.sink", w.name),
+		attribute.String("mtconnect.sink_type", w.sinkType),
+		attribute.String("mtconnect.message_type", msg.Type),
+		attribute.String("mtconnect.session_id", msg.SessionID),
+		attribute.String("mtconnect.machine_id", msg.MachineID),
+	))
+	defer span.End()
+	if span.SpanContext().IsValid() {
+		if msg.Headers == nil {
+			msg.Headers = make(map[string]string)
+		}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(msg.Headers))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sinkDeliveryTimeout)
+	defer cancel()
+	started := time.Now()
+	err = w.producer.Produce(ctx, msg)
+	w.metrics.ObserveProduce(w.name, w.sinkType, time.Since(started), err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		w.recordFailure(err)
+		return
+	}
+	if !w.deferred {
+		w.recordDelivered(1)
+	}
+}
+
+// recordDelivered учитывает доставленные сообщения
+func (w *sinkWorker) recordDelivered(count int) {
+	w.delivered.Add(uint64(count))
+	w.mu.Lock()
+	w.lastDelivery = time.Now()
+	w.mu.Unlock()
+}
+
+func (w *sinkWorker) recordFailure(err error) {
+	w.failed.Add(1)
+	w.mu.Lock()
+	w.lastError = err.Error()
+	w.mu.Unlock()
+	sinksLog.Error("синк не смог отправить сообщение", "sink", w.name, logging.Err(err))
+}
+
+// recordLost учитывает сообщение, которое продюсер принял, но не смог доставить, а сохранить его некуда
+func (w *sinkWorker) recordLost(msg *entities.Message) error {
+	err := fmt.Errorf("сообщение %s не доставлено, outbox для синка не включен", msg.ID)
+	w.recordFailure(err)
+	return err
+}
+
+func (w *sinkWorker) close() error {
+	close(w.queue)
+	<-w.done
+	return w.producer.Close()
+}
+
+func (w *sinkWorker) stats() entities.SinkStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stats := entities.SinkStats{
+		Name:          w.name,
+		Type:          w.sinkType,
+		Queued:        len(w.queue),
+		QueueCapacity: cap(w.queue),
+		Delivered:     w.delivered.Load(),
+		Failed:        w.failed.Load(),
+		Dropped:       w.dropped.Load(),
+		LastError:     w.lastError,
+	}
+	if !w.lastDelivery.IsZero() {
+		lastDelivery := w.lastDelivery
+		stats.LastDelivery = &lastDelivery
+	}
+	if provider, ok := w.producer.(interfaces.SinkDetailsProvider); ok {
+		stats.Details = provider.SinkDetails()
+	}
+	return stats
+}
@@ -0,0 +1,30 @@
+package producers
+
+import (
+	"MTConnect/internal/domain/entities"
+	"strings"
+)
+
+// renderTopic подставляет атрибуты сообщения в шаблон топика.
+// sanitize приводит подставляемые значения к допустимому для брокера виду.
+func renderTopic(template string, msg *entities.Message, sanitize func(string) string) string {
+	if !strings.Contains(template, "{") {
+		return template
+	}
+	manufacturer := msg.Manufacturer
+	if manufacturer == "" {
+		manufacturer = "unknown"
+	}
+	replacer := strings.NewReplacer(
+		"{manufacturer}", sanitize(manufacturer),
+		"{machineId}", sanitize(msg.MachineID),
+		"{sessionId}", sanitize(msg.SessionID),
+		"{type}", sanitize(msg.Type),
+	)
+	return replacer.Replace(template)
+}
+
+// sanitizeMQTTTopicLevel заменяет символы, недопустимые внутри уровня топика MQTT
+func sanitizeMQTTTopicLevel(value string) string {
+	return strings.NewReplacer("/", "_", "+", "_", "#", "_").Replace(value)
+}
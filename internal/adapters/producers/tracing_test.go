@@ -0,0 +1,168 @@
+package producers
+
+import (
+	"MTConnect/internal/adapters/tracing/tracingtest"
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	metadataAPI "github.com/segmentio/kafka-go/protocol/metadata"
+	produceAPI "github.com/segmentio/kafka-go/protocol/produce"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// fakeKafkaTransport отвечает на запросы писателя kafka-go вместо брокера и запоминает заголовки записей
+type fakeKafkaTransport struct {
+	mu      sync.Mutex
+	headers map[string][]map[string]string
+}
+
+func (t *fakeKafkaTransport) RoundTrip(_ context.Context, _ net.Addr, request protocol.Message) (protocol.Message, error) {
+	switch request := request.(type) {
+	case *metadataAPI.Request:
+		response := &metadataAPI.Response{Brokers: []metadataAPI.ResponseBroker{{NodeID: 1, Host: "localhost", Port: 9092}}}
+		for _, topic := range request.TopicNames {
+			response.Topics = append(response.Topics, metadataAPI.ResponseTopic{
+				Name:       topic,
+				Partitions: []metadataAPI.ResponsePartition{{PartitionIndex: 0, LeaderID: 1}},
+			})
+		}
+		return response, nil
+	case *produceAPI.Request:
+		response := &produceAPI.Response{}
+		for _, topic := range request.Topics {
+			responseTopic := produceAPI.ResponseTopic{Topic: topic.Topic}
+			for _, partition := range topic.Partitions {
+				if err := t.record(topic.Topic, partition.RecordSet.Records); err != nil {
+					return nil, err
+				}
+				responseTopic.Partitions = append(responseTopic.Partitions, produceAPI.ResponsePartition{Partition: partition.Partition})
+			}
+			response.Topics = append(response.Topics, responseTopic)
+		}
+		return response, nil
+	}
+	return nil, errors.New("неожиданный запрос к Kafka")
+}
+
+func (t *fakeKafkaTransport) record(topic string, records protocol.RecordReader) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for {
+		record, err := records.ReadRecord()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		headers := make(map[string]string, len(record.Headers))
+		for _, header := range record.Headers {
+			headers[header.Key] = string(header.Value)
+		}
+		t.headers[topic] = append(t.headers[topic], headers)
+	}
+}
+
+func (t *fakeKafkaTransport) recorded(topic string) []map[string]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]map[string]string(nil), t.headers[topic]...)
+}
+
+type nopSinkMetrics struct{}
+
+func (nopSinkMetrics) ObservePoll(string, string, time.Duration)             {}
+func (nopSinkMetrics) FetchError(string, string)                             {}
+func (nopSinkMetrics) ObserveParse(string, time.Duration)                    {}
+func (nopSinkMetrics) DataItems(string, int, int)                            {}
+func (nopSinkMetrics) ObserveProduce(string, string, time.Duration, error)   {}
+func (nopSinkMetrics) ObserveHTTPRequest(string, string, int, time.Duration) {}
+func (nopSinkMetrics) ForgetSession(string)                                  {}
+func (nopSinkMetrics) Handler() http.Handler                                 { return http.NotFoundHandler() }
+
+// TestSinkTraceExport проверяет, что спан отправки синка продолжает трассу из заголовков сообщения,
+// экспортируется коллектору по OTLP/HTTP и передает свой контекст в заголовках записи Kafka
+func TestSinkTraceExport(t *testing.T) {
+	collector := tracingtest.NewCollector(t)
+	stopTracing := collector.Start(t, "mtconnect-test")
+
+	cfg := &config.AppConfig{Sinks: []config.SinkConfig{{
+		Name:        "kafka",
+		Type:        config.SinkTypeKafka,
+		Encoding:    "json",
+		CloudEvents: "none",
+		QueueSize:   100,
+		Kafka: &config.KafkaSinkConfig{
+			Brokers:        []string{"localhost:9092"},
+			Topic:          "mtconnect_data",
+			BatchSize:      1,
+			BatchTimeoutMs: 10,
+		},
+	}}}
+	registry, err := NewSinkRegistry(cfg, NewOutboxRegistry(), nopSinkMetrics{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &fakeKafkaTransport{headers: map[string][]map[string]string{}}
+	registry.sinks[0].producer.(*KafkaProducer).writer.Transport = transport
+
+	// Сообщение несет контекст спана опроса, как его сохраняет PollingService
+	ctx, poll := otel.Tracer("test").Start(context.Background(), "poll")
+	msg := &entities.Message{ID: "m1", Type: entities.MessageTypeSnapshot, SessionID: "session-1", MachineID: "Mazak", Payload: testMachineData()}
+	msg.Headers = map[string]string{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(msg.Headers))
+	poll.End()
+	if err := registry.Produce(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Остановка провайдера отправляет коллектору все завершенные спаны
+	stopTracing()
+
+	if collector.ServiceName() != "mtconnect-test" {
+		t.Errorf("service.name ресурса = '%s'", collector.ServiceName())
+	}
+	spans := collector.SpansNamed("Produce")
+	if len(spans) != 1 {
+		t.Fatalf("коллектор получил %d спанов Produce, ожидался 1", len(spans))
+	}
+	produce := spans[0]
+	pollID := poll.SpanContext().SpanID()
+	if !bytes.Equal(produce.GetParentSpanId(), pollID[:]) {
+		t.Error("спан Produce не продолжает трассу из заголовков сообщения")
+	}
+	if produce.GetKind() != tracepb.Span_SPAN_KIND_PRODUCER {
+		t.Errorf("вид спана Produce = %v", produce.GetKind())
+	}
+	for key, want := range map[string]string{"mtconnect.session_id": "session-1", "mtconnect.machine_id": "Mazak", "mtconnect.sink": "kafka",
+		"mtconnect.sink_type": config.SinkTypeKafka, "mtconnect.message_type": entities.MessageTypeSnapshot} {
+		if got, _ := tracingtest.Attribute(produce.GetAttributes(), key); got != want {
+			t.Errorf("атрибут спана Produce %s = '%s', ожидалось '%s'", key, got, want)
+		}
+	}
+
+	// В заголовках записи Kafka передается контекст спана отправки
+	want := "00-" + hex.EncodeToString(produce.GetTraceId()) + "-" + hex.EncodeToString(produce.GetSpanId()) + "-01"
+	records := transport.recorded("mtconnect_data")
+	if len(records) != 1 || records[0]["traceparent"] != want {
+		t.Errorf("заголовки записей Kafka: %v, ожидался traceparent '%s'", records, want)
+	}
+}
+
+var _ kafka.RoundTripper = (*fakeKafkaTransport)(nil)
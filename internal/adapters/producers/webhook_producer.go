@@ -0,0 +1,484 @@
+package producers
+
+import (
+	"MTConnect/internal/buildinfo"
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var webhookLog = logging.Logger(logging.ComponentWebhook)
+
+const (
+	webhookSignatureHeader = "X-MTConnect-Signature"
+	webhookTimestampHeader = "X-MTConnect-Timestamp"
+	webhookMaxBackoff      = 30 * time.Second
+)
+
+// WebhookProducer отправляет сообщения HTTP POST-запросами на настроенные адреса.
+// Каждый адрес обслуживается независимо: повторы с экспоненциальной задержкой,
+// приостановка после серии неудачных доставок и собственная статистика.
+// Сообщение считается доставленным, только если его приняли все адреса;
+// при частичной доставке повторная отправка выполняется лишь на не принявшие его адреса.
+type WebhookProducer struct {
+	cfg     config.WebhookSinkConfig
+	client  *http.Client
+	targets []*webhookTarget
+
+	// fallback сохраняет сообщения из пакетов, отправка которых не удалась
+	fallback func(msg *entities.Message) error
+	// onDelivered подтверждает доставку сообщений в пакетном режиме
+	onDelivered func(count int)
+
+	mu    sync.Mutex
+	batch []*entities.Message
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewWebhookSink создает продюсер HTTP webhook для синка
+func NewWebhookSink(sink config.SinkConfig, _ *config.AppConfig) (interfaces.DataProducer, error) {
+	if sink.Webhook == nil {
+		return nil, fmt.Errorf("для синка '%s' не задан раздел webhook", sink.Name)
+	}
+	cfg := *sink.Webhook
+	if len(cfg.URLs) == 0 {
+		return nil, fmt.Errorf("для синка '%s' не заданы адреса urls", sink.Name)
+	}
+	if cfg.BatchSize > 1 && sink.Encoding != "" && sink.Encoding != "json" {
+		return nil, fmt.Errorf("пакетная отправка синка '%s' поддерживается только для формата json", sink.Name)
+	}
+
+	producer := &WebhookProducer{
+		cfg:    cfg,
+		client: &http.Client{Timeout: time.Duration(cfg.TimeoutMs) * time.Millisecond},
+		stop:   make(chan struct{}),
+	}
+	for _, rawURL := range cfg.URLs {
+		parsed, err := url.Parse(rawURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("некорректный адрес webhook '%s' в синке '%s'", rawURL, sink.Name)
+		}
+		producer.targets = append(producer.targets, &webhookTarget{
+			id:          webhookTargetID(rawURL),
+			url:         rawURL,
+			displayName: entities.RedactURL(parsed),
+		})
+	}
+
+	if cfg.BatchSize > 1 {
+		producer.wg.Add(1)
+		go producer.flushLoop(time.Duration(cfg.BatchIntervalMs) * time.Millisecond)
+	}
+	return producer, nil
+}
+
+// Async сообщает, что в пакетном режиме сообщения доставляются после возврата из Produce
+func (p *WebhookProducer) Async() bool {
+	return p.cfg.BatchSize > 1
+}
+
+// SetFallback задает обработчик сообщений из пакетов, которые не удалось доставить
+func (p *WebhookProducer) SetFallback(fallback func(msg *entities.Message) error) {
+	p.fallback = fallback
+}
+
+// SetDeliveryHandler задает обработчик подтверждения доставки в пакетном режиме
+func (p *WebhookProducer) SetDeliveryHandler(onDelivered func(count int)) {
+	p.onDelivered = onDelivered
+}
+
+// Produce отправляет сообщение сразу или добавляет его в текущий пакет.
+// Сообщение, уже принятое частью адресов, отправляется отдельно только на оставшиеся адреса.
+func (p *WebhookProducer) Produce(ctx context.Context, msg *entities.Message) error {
+	if p.cfg.BatchSize <= 1 || len(msg.Targets) > 0 {
+		return p.send(ctx, []*entities.Message{msg})
+	}
+
+	p.mu.Lock()
+	p.batch = append(p.batch, msg)
+	var ready []*entities.Message
+	if len(p.batch) >= p.cfg.BatchSize {
+		ready = p.batch
+		p.batch = nil
+	}
+	p.mu.Unlock()
+
+	if ready != nil {
+		return p.sendBatch(ctx, ready)
+	}
+	return nil
+}
+
+// Close отправляет накопленный пакет и останавливает фоновую отправку
+func (p *WebhookProducer) Close() error {
+	if p.cfg.BatchSize > 1 {
+		close(p.stop)
+		p.wg.Wait()
+	}
+	p.mu.Lock()
+	pending := p.batch
+	p.batch = nil
+	p.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sinkDeliveryTimeout)
+	defer cancel()
+	return p.sendBatch(ctx, pending)
+}
+
+// SinkDetails возвращает статистику доставки по каждому адресу
+func (p *WebhookProducer) SinkDetails() interface{} {
+	stats := make([]entities.WebhookTargetStats, 0, len(p.targets))
+	for _, target := range p.targets {
+		stats = append(stats, target.stats())
+	}
+	return stats
+}
+
+// flushLoop периодически отправляет неполный пакет, чтобы сообщения не задерживались
+func (p *WebhookProducer) flushLoop(interval time.Duration) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			pending := p.batch
+			p.batch = nil
+			p.mu.Unlock()
+			if len(pending) == 0 {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), sinkDeliveryTimeout)
+			if err := p.sendBatch(ctx, pending); err != nil {
+				webhookLog.Error("пакет сообщений не доставлен", "messages", len(pending), logging.Err(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// sendBatch отправляет пакет. Сообщения пакета уже учтены синком как принятые, поэтому при неудаче
+// они передаются в fallback: в локальную очередь или, без нее, в учет потерянных сообщений синка.
+func (p *WebhookProducer) sendBatch(ctx context.Context, messages []*entities.Message) error {
+	err := p.send(ctx, messages)
+	if err == nil || p.fallback == nil {
+		return err
+	}
+	for _, msg := range messages {
+		if persistErr := p.fallback(msg); persistErr != nil {
+			webhookLog.Error("сообщение потеряно", "messageId", msg.ID, logging.Err(persistErr))
+		}
+	}
+	return nil
+}
+
+// send формирует тело запроса и рассылает его параллельно на адреса, еще не принявшие сообщения.
+// Если часть адресов не приняла запрос, в Targets сообщений остаются только они, и возвращается ошибка.
+func (p *WebhookProducer) send(ctx context.Context, messages []*entities.Message) error {
+	// Пакеты собираются только из новых сообщений, поэтому получатели у всех сообщений запроса общие
+	targets := p.pendingTargets(messages[0])
+	if len(targets) == 0 {
+		webhookLog.Warn("получатели сообщения удалены из конфигурации, сообщение пропущено", "messageId", messages[0].ID)
+		return nil
+	}
+	body, headers := p.buildRequest(messages)
+
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target *webhookTarget) {
+			defer wg.Done()
+			errs[i] = p.deliver(ctx, target, body, headers)
+		}(i, target)
+	}
+	wg.Wait()
+
+	var pending, failures []string
+	for i, err := range errs {
+		if err != nil {
+			pending = append(pending, targets[i].id)
+			failures = append(failures, fmt.Sprintf("%s: %v", targets[i].displayName, err))
+		}
+	}
+	for _, msg := range messages {
+		msg.Targets = pending
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("адреса webhook не приняли сообщение (%d из %d): %s", len(pending), len(targets), strings.Join(failures, "; "))
+	}
+	if p.Async() && p.onDelivered != nil {
+		p.onDelivered(len(messages))
+	}
+	return nil
+}
+
+// pendingTargets возвращает адреса, которым сообщение еще не доставлено
+func (p *WebhookProducer) pendingTargets(msg *entities.Message) []*webhookTarget {
+	if len(msg.Targets) == 0 {
+		return p.targets
+	}
+	var targets []*webhookTarget
+	for _, target := range p.targets {
+		if slices.Contains(msg.Targets, target.id) {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// buildRequest формирует тело и заголовки запроса. Пакет отправляется JSON-массивом.
+func (p *WebhookProducer) buildRequest(messages []*entities.Message) ([]byte, http.Header) {
+	headers := http.Header{}
+	for name, value := range p.cfg.Headers {
+		headers.Set(name, value)
+	}
+	headers.Set("User-Agent", buildinfo.ServiceName+"/"+buildinfo.Version)
+
+	var body []byte
+	if len(messages) == 1 {
+		msg := messages[0]
+		body = msg.Value
+		headers.Set("Content-Type", msg.ContentType)
+		headers.Set("X-MTConnect-Message-Type", msg.Type)
+		headers.Set("X-MTConnect-Machine-Id", msg.MachineID)
+		for name, value := range msg.Headers {
+			headers.Set(name, value)
+		}
+	} else {
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, msg := range messages {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(msg.Value)
+		}
+		buf.WriteByte(']')
+		body = buf.Bytes()
+		headers.Set("Content-Type", batchContentType(messages))
+		headers.Set("X-MTConnect-Batch-Size", strconv.Itoa(len(messages)))
+	}
+
+	if p.cfg.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		headers.Set(webhookTimestampHeader, timestamp)
+		headers.Set(webhookSignatureHeader, "sha256="+signWebhookBody(p.cfg.Secret, timestamp, body))
+	}
+	return body, headers
+}
+
+// batchContentType возвращает тип пакета: пакет конвертов CloudEvents или обычный JSON-массив
+func batchContentType(messages []*entities.Message) string {
+	for _, msg := range messages {
+		if msg.ContentType != contentTypeCloudEvents {
+			return contentTypeJSON
+		}
+	}
+	return contentTypeCloudEventsBatch
+}
+
+// signWebhookBody вычисляет HMAC-SHA256 от строки "<timestamp>.<body>".
+// Метка времени входит в подпись, чтобы получатель мог отклонять повторно отправленные запросы.
+func signWebhookBody(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver отправляет запрос на один адрес с повторами и экспоненциальной задержкой
+func (p *WebhookProducer) deliver(ctx context.Context, target *webhookTarget, body []byte, headers http.Header) error {
+	if until, paused := target.pausedUntil(); paused {
+		target.recordSkipped()
+		return fmt.Errorf("адрес приостановлен до %s", until.Format(time.RFC3339))
+	}
+
+	backoff := time.Duration(p.cfg.RetryBackoffMs) * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		statusCode, err := p.post(ctx, target.url, body, headers)
+		latency := time.Since(start)
+		if err == nil {
+			target.recordSuccess(statusCode, latency)
+			return nil
+		}
+		if !isRetryableWebhookStatus(statusCode) || attempt >= p.cfg.MaxRetries || ctx.Err() != nil {
+			p.recordTargetFailure(target, statusCode, latency, err)
+			return err
+		}
+
+		target.recordRetry()
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			p.recordTargetFailure(target, statusCode, latency, err)
+			return err
+		}
+		backoff *= 2
+		if backoff > webhookMaxBackoff {
+			backoff = webhookMaxBackoff
+		}
+	}
+}
+
+func (p *WebhookProducer) recordTargetFailure(target *webhookTarget, statusCode int, latency time.Duration, err error) {
+	pauseDuration := time.Duration(p.cfg.PauseDurationSec) * time.Second
+	if target.recordFailure(statusCode, latency, err, p.cfg.PauseAfterFailures, pauseDuration) {
+		webhookLog.Warn("получатель приостановлен после неудачных доставок подряд", "target", target.displayName,
+			"pause", pauseDuration.String(), "failures", p.cfg.PauseAfterFailures, logging.Err(err))
+	}
+}
+
+// post выполняет один HTTP-запрос. Код ответа 0 означает сетевую ошибку.
+func (p *WebhookProducer) post(ctx context.Context, targetURL string, body []byte, headers http.Header) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	req.Header = headers.Clone()
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("сервер ответил со статусом %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// isRetryableWebhookStatus определяет, имеет ли смысл повторять запрос.
+// Ошибки клиента (4xx) не повторяются, кроме таймаута запроса и превышения лимита.
+func isRetryableWebhookStatus(statusCode int) bool {
+	if statusCode == 0 || statusCode >= 500 {
+		return true
+	}
+	return statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests
+}
+
+// webhookTargetID возвращает устойчивый идентификатор адреса для учета частичной доставки.
+// Сам адрес не сохраняется вместе с сообщением, так как может содержать токены.
+func webhookTargetID(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return hex.EncodeToString(sum[:8])
+}
+
+// webhookTarget - состояние доставки на один адрес
+type webhookTarget struct {
+	id          string
+	url         string
+	displayName string
+
+	mu                  sync.Mutex
+	delivered           uint64
+	failed              uint64
+	retries             uint64
+	skipped             uint64
+	consecutiveFailures int
+	pauseUntil          time.Time
+	lastStatusCode      int
+	lastLatency         time.Duration
+	lastError           string
+	lastDelivery        time.Time
+}
+
+func (t *webhookTarget) pausedUntil() (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.pauseUntil, time.Now().Before(t.pauseUntil)
+}
+
+func (t *webhookTarget) recordSkipped() {
+	t.mu.Lock()
+	t.skipped++
+	t.mu.Unlock()
+}
+
+func (t *webhookTarget) recordRetry() {
+	t.mu.Lock()
+	t.retries++
+	t.mu.Unlock()
+}
+
+func (t *webhookTarget) recordSuccess(statusCode int, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.pauseUntil.IsZero() {
+		webhookLog.Info("получатель снова принимает сообщения", "target", t.displayName)
+	}
+	t.delivered++
+	t.consecutiveFailures = 0
+	t.pauseUntil = time.Time{}
+	t.lastStatusCode = statusCode
+	t.lastLatency = latency
+	t.lastDelivery = time.Now()
+}
+
+// recordFailure учитывает неудачную доставку и возвращает true, если адрес был приостановлен.
+// После окончания паузы первая же неудачная попытка снова приостанавливает адрес.
+func (t *webhookTarget) recordFailure(statusCode int, latency time.Duration, err error, pauseAfter int, pauseDuration time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failed++
+	t.consecutiveFailures++
+	t.lastStatusCode = statusCode
+	t.lastLatency = latency
+	t.lastError = err.Error()
+	if t.consecutiveFailures >= pauseAfter {
+		t.pauseUntil = time.Now().Add(pauseDuration)
+		return true
+	}
+	return false
+}
+
+func (t *webhookTarget) stats() entities.WebhookTargetStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := entities.WebhookTargetStats{
+		URL:                 t.displayName,
+		Delivered:           t.delivered,
+		Failed:              t.failed,
+		Retries:             t.retries,
+		Skipped:             t.skipped,
+		ConsecutiveFailures: t.consecutiveFailures,
+		LastStatusCode:      t.lastStatusCode,
+		LastLatencyMs:       t.lastLatency.Milliseconds(),
+		LastError:           t.lastError,
+	}
+	if time.Now().Before(t.pauseUntil) {
+		pauseUntil := t.pauseUntil
+		stats.PausedUntil = &pauseUntil
+	}
+	if !t.lastDelivery.IsZero() {
+		lastDelivery := t.lastDelivery
+		stats.LastDelivery = &lastDelivery
+	}
+	return stats
+}
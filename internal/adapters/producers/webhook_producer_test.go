@@ -0,0 +1,164 @@
+package producers
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookReceiver - получатель webhook, который можно временно перевести в режим ошибок
+type webhookReceiver struct {
+	failing atomic.Bool
+
+	mu       sync.Mutex
+	accepted int
+	rejected int
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failing.Load() {
+		r.rejected++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	r.accepted++
+}
+
+func (r *webhookReceiver) counts() (accepted, rejected int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.accepted, r.rejected
+}
+
+func startWebhookReceivers(t *testing.T, count int) ([]*webhookReceiver, []string) {
+	t.Helper()
+	receivers := make([]*webhookReceiver, count)
+	urls := make([]string, count)
+	for i := range receivers {
+		receivers[i] = &webhookReceiver{}
+		server := httptest.NewServer(receivers[i])
+		t.Cleanup(server.Close)
+		urls[i] = server.URL
+	}
+	return receivers, urls
+}
+
+func testWebhookConfig(urls []string, batchSize int) *config.WebhookSinkConfig {
+	return &config.WebhookSinkConfig{
+		URLs:               urls,
+		TimeoutMs:          1000,
+		RetryBackoffMs:     1,
+		BatchSize:          batchSize,
+		BatchIntervalMs:    60000,
+		PauseAfterFailures: 100,
+		PauseDurationSec:   1,
+	}
+}
+
+func waitForCondition(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("не дождались: %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWebhookPartialDeliveryRetriesOnlyFailedTargets(t *testing.T) {
+	receivers, urls := startWebhookReceivers(t, 3)
+	receivers[1].failing.Store(true)
+	receivers[2].failing.Store(true)
+
+	producer, err := NewWebhookSink(config.SinkConfig{Name: "webhook", Webhook: testWebhookConfig(urls, 1)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outbox, err := NewOutbox("webhook", t.TempDir(), 0, 10*time.Millisecond, producer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outbox.Close()
+
+	msg := &entities.Message{ID: "m1", Type: entities.MessageTypeSnapshot, Value: []byte(`{}`), ContentType: contentTypeJSON}
+	if err := outbox.Produce(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if outbox.Depth() != 1 {
+		t.Fatalf("сообщение, не принятое частью адресов, не сохранено в outbox: depth = %d", outbox.Depth())
+	}
+	if len(msg.Targets) != 2 {
+		t.Fatalf("в outbox сохранены получатели %v, ожидались два не принявших сообщение адреса", msg.Targets)
+	}
+
+	// Второй адрес восстановился: при повторе сообщение получает только он, а третий остается в очереди
+	receivers[1].failing.Store(false)
+	waitForCondition(t, "доставка на второй адрес", func() bool { accepted, _ := receivers[1].counts(); return accepted == 1 })
+	_, rejectedBefore := receivers[2].counts()
+	waitForCondition(t, "повтор на третий адрес", func() bool { _, rejected := receivers[2].counts(); return rejected > rejectedBefore })
+	if outbox.Depth() != 1 {
+		t.Fatalf("сообщение удалено из outbox до доставки на все адреса")
+	}
+
+	receivers[2].failing.Store(false)
+	waitForCondition(t, "опустошение outbox", func() bool { return outbox.Depth() == 0 })
+	for i, receiver := range receivers {
+		if accepted, _ := receiver.counts(); accepted != 1 {
+			t.Errorf("адрес #%d принял сообщение %d раз, ожидалось ровно 1", i+1, accepted)
+		}
+	}
+}
+
+func TestWebhookBatchDeliveryIsCountedOnAcknowledgement(t *testing.T) {
+	tests := []struct {
+		name          string
+		failing       bool
+		wantDelivered uint64
+		wantFailed    uint64
+	}{
+		{"доставлен", false, 2, 0},
+		{"не доставлен без outbox", true, 0, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			receivers, urls := startWebhookReceivers(t, 1)
+			receivers[0].failing.Store(test.failing)
+			outbox := false
+			cfg := &config.AppConfig{Sinks: []config.SinkConfig{{
+				Name:        "webhook",
+				Type:        config.SinkTypeWebhook,
+				CloudEvents: "none",
+				QueueSize:   10,
+				Outbox:      &outbox,
+				Webhook:     testWebhookConfig(urls, 2),
+			}}}
+			registry, err := NewSinkRegistry(cfg, NewOutboxRegistry(), nopSinkMetrics{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, id := range []string{"m1", "m2"} {
+				msg := &entities.Message{ID: id, Type: entities.MessageTypeSnapshot, Payload: map[string]string{"id": id}}
+				if err := registry.Produce(context.Background(), msg); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := registry.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			stats := registry.SinkStats()[0]
+			if stats.Delivered != test.wantDelivered || stats.Failed != test.wantFailed {
+				t.Errorf("Delivered = %d, Failed = %d, ожидалось %d и %d", stats.Delivered, stats.Failed, test.wantDelivered, test.wantFailed)
+			}
+		})
+	}
+}
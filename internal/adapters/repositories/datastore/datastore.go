@@ -8,14 +8,16 @@ import (
 
 // DataStore - потокобезопасное in-memory хранилище данных
 type DataStore struct {
-	mu   sync.RWMutex
-	data map[string]entities.MachineData
+	mu        sync.RWMutex
+	data      map[string]entities.MachineData
+	published map[string]entities.PublishedState
 }
 
 // NewDataStore создает новый экземпляр DataStore
 func NewDataStore() interfaces.DataStoreRepository {
 	return &DataStore{
-		data: make(map[string]entities.MachineData),
+		data:      make(map[string]entities.MachineData),
+		published: make(map[string]entities.PublishedState),
 	}
 }
 
@@ -33,3 +35,18 @@ func (ds *DataStore) Get(machineId string) (entities.MachineData, bool) {
 	machineData, found := ds.data[machineId]
 	return machineData, found
 }
+
+// SetPublished сохраняет последнее опубликованное состояние станка
+func (ds *DataStore) SetPublished(machineId string, state entities.PublishedState) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.published[machineId] = state
+}
+
+// GetPublished извлекает последнее опубликованное состояние станка
+func (ds *DataStore) GetPublished(machineId string) (entities.PublishedState, bool) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	state, found := ds.published[machineId]
+	return state, found
+}
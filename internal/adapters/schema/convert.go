@@ -0,0 +1,274 @@
+// Package schema преобразует сущности домена в записи схемы mtconnect.v1,
+// общие для сериализации синков и ответов gRPC API
+package schema
+
+import (
+	mtconnectv1 "MTConnect/api/mtconnect/v1"
+	"MTConnect/internal/domain/entities"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ToMessage преобразует полезную нагрузку сообщения или события потока в типизированную запись схемы mtconnect.v1
+func ToMessage(payload interface{}) (proto.Message, error) {
+	switch p := payload.(type) {
+	case entities.MachineData:
+		return machineDataToProto(p), nil
+	case map[string]json.RawMessage:
+		// Снимок, сокращенный фильтром полей синка: невыбранные поля остаются пустыми
+		raw, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		var data entities.MachineData
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		return machineDataToProto(data), nil
+	case entities.MachineDataDelta:
+		return deltaToProto(p), nil
+	case entities.AlarmEvent:
+		return alarmEventToProto(p), nil
+	case entities.LifecycleEvent:
+		return lifecycleEventToProto(p), nil
+	case entities.Observation:
+		return observationToProto(p), nil
+	}
+	return nil, fmt.Errorf("тип данных %T не описан схемой mtconnect.v1", payload)
+}
+
+func machineDataToProto(data entities.MachineData) *mtconnectv1.MachineData {
+	result := &mtconnectv1.MachineData{
+		MachineId:           data.MachineId,
+		Id:                  data.Id,
+		Timestamp:           data.Timestamp,
+		IsEnabled:           optionalBool(data.IsEnabled),
+		IsInEmergency:       optionalBool(data.IsInEmergency),
+		MachineState:        data.MachineState,
+		ProgramMode:         data.ProgramMode,
+		TmMode:              data.TmMode,
+		HandleRetraceStatus: optionalBool(data.HandleRetraceStatus),
+		AxisMovementStatus:  stringMap(data.AxisMovementStatus),
+		MstbStatus:          data.MstbStatus,
+		EmergencyStatus:     data.EmergencyStatus,
+		AlarmStatus:         data.AlarmStatus,
+		EditStatus:          data.EditStatus,
+		ManualMode:          optionalBool(data.ManualMode),
+		WriteStatus:         data.WriteStatus,
+		LabelSkipStatus:     optionalString(data.LabelSkipStatus),
+		WarningStatus:       data.WarningStatus,
+		BatteryStatus:       optionalString(data.BatteryStatus),
+		ActiveToolNumber:    data.ActiveToolNumber,
+		ToolOffsetNumber:    data.ToolOffsetNumber,
+		FeedRate:            data.FeedRate,
+		FeedOverride:        data.FeedOverride,
+		HasAlarms:           optionalBool(data.HasAlarms),
+		PartsCount:          data.PartsCount,
+		AccumulatedTime:     data.AccumulatedTime,
+		ContourFeedRate:     optionalString(data.ContourFeedRate),
+		JogOverride:         optionalString(data.JogOverride),
+	}
+	for _, axis := range data.AxisInfos {
+		result.AxisInfos = append(result.AxisInfos, &mtconnectv1.Component{
+			Id: axis.ID, Name: axis.Name, Type: axis.Type, Data: stringMap(axis.Data),
+		})
+	}
+	for _, spindle := range data.SpindleInfos {
+		result.SpindleInfos = append(result.SpindleInfos, &mtconnectv1.Component{
+			Id: spindle.ID, Name: spindle.Name, Type: spindle.Type, Data: stringMap(spindle.Data),
+		})
+	}
+	for _, alarm := range data.Alarms {
+		result.Alarms = append(result.Alarms, &mtconnectv1.Alarm{
+			DataItemId:     stringValue(alarm["dataItemId"]),
+			NativeCode:     stringValue(alarm["nativeCode"]),
+			NativeSeverity: stringValue(alarm["nativeSeverity"]),
+			Qualifier:      stringValue(alarm["qualifier"]),
+			Level:          stringValue(alarm["level"]),
+			Type:           stringValue(alarm["type"]),
+			ComponentId:    stringValue(alarm["componentId"]),
+			ComponentName:  stringValue(alarm["componentName"]),
+			Message:        stringValue(alarm["message"]),
+			Timestamp:      stringValue(alarm["timestamp"]),
+		})
+	}
+	for _, condition := range data.Conditions {
+		state := &mtconnectv1.ConditionState{
+			DataItemId:    condition.DataItemId,
+			Type:          condition.Type,
+			ComponentId:   condition.ComponentId,
+			ComponentName: condition.ComponentName,
+			State:         condition.State,
+			Timestamp:     condition.Timestamp,
+		}
+		for _, activation := range condition.Activations {
+			state.Activations = append(state.Activations, &mtconnectv1.ConditionActivation{
+				NativeCode:     activation.NativeCode,
+				NativeSeverity: activation.NativeSeverity,
+				Qualifier:      activation.Qualifier,
+				Level:          activation.Level,
+				Message:        activation.Message,
+				Timestamp:      activation.Timestamp,
+			})
+		}
+		result.Conditions = append(result.Conditions, state)
+	}
+	if program := data.CurrentProgram; program != nil {
+		result.CurrentProgram = &mtconnectv1.CurrentProgram{
+			Block:          program.Block,
+			Program:        program.Program,
+			ProgramComment: program.ProgramComment,
+			ProgramHeader:  program.ProgramHeader,
+			Line:           program.Line,
+			LineNumber:     program.LineNumber,
+			LineLabel:      program.LineLabel,
+		}
+	}
+	return result
+}
+
+func deltaToProto(delta entities.MachineDataDelta) *mtconnectv1.MachineDataDelta {
+	result := &mtconnectv1.MachineDataDelta{
+		MachineId: delta.MachineId,
+		Id:        delta.Id,
+		Timestamp: delta.Timestamp,
+		Changed:   make(map[string]*mtconnectv1.FieldValue, len(delta.Changed)),
+		Removed:   delta.Removed,
+	}
+	for path, value := range delta.Changed {
+		result.Changed[path] = fieldValue(value)
+	}
+	return result
+}
+
+func alarmEventToProto(event entities.AlarmEvent) *mtconnectv1.AlarmEvent {
+	result := &mtconnectv1.AlarmEvent{
+		EventType:      event.EventType,
+		SessionId:      event.SessionID,
+		MachineId:      event.MachineId,
+		DataItemId:     event.DataItemId,
+		NativeCode:     event.NativeCode,
+		NativeSeverity: event.NativeSeverity,
+		Qualifier:      event.Qualifier,
+		Level:          event.Level,
+		PreviousLevel:  event.PreviousLevel,
+		Type:           event.Type,
+		ComponentId:    event.ComponentId,
+		ComponentName:  event.ComponentName,
+		Message:        event.Message,
+		StartTimeMs:    unixMillis(event.StartTime),
+		DurationMs:     event.DurationMs,
+		Reason:         event.Reason,
+	}
+	if event.EndTime != nil {
+		result.EndTimeMs = proto.Int64(unixMillis(*event.EndTime))
+	}
+	return result
+}
+
+func lifecycleEventToProto(event entities.LifecycleEvent) *mtconnectv1.LifecycleEvent {
+	return &mtconnectv1.LifecycleEvent{
+		EventType:    event.EventType,
+		SessionId:    event.SessionID,
+		MachineId:    event.MachineId,
+		Manufacturer: event.Manufacturer,
+		EndpointUrl:  event.EndpointURL,
+		TimestampMs:  unixMillis(event.Timestamp),
+		Message:      event.Message,
+		Attributes:   event.Attributes,
+	}
+}
+
+func observationToProto(observation entities.Observation) *mtconnectv1.Observation {
+	return &mtconnectv1.Observation{
+		MachineId:      observation.MachineId,
+		DeviceUuid:     observation.DeviceUUID,
+		Sequence:       int64(observation.Sequence),
+		Timestamp:      observation.Timestamp,
+		DataItemId:     observation.DataItemId,
+		Name:           observation.Name,
+		Category:       observation.Category,
+		Type:           observation.Type,
+		SubType:        observation.SubType,
+		ComponentPath:  observation.ComponentPath,
+		Value:          observation.Value,
+		NativeCode:     observation.NativeCode,
+		NativeSeverity: observation.NativeSeverity,
+		Qualifier:      observation.Qualifier,
+		Message:        observation.Message,
+	}
+}
+
+// optionalBool возвращает nil для значений "UNAVAILABLE" и других нелогических значений
+func optionalBool(value interface{}) *bool {
+	if b, ok := value.(bool); ok {
+		return proto.Bool(b)
+	}
+	return nil
+}
+
+// optionalString возвращает nil для пустых и недоступных значений
+func optionalString(value interface{}) *string {
+	s, ok := value.(string)
+	if !ok || s == "" || s == entities.ConditionUnavailable {
+		return nil
+	}
+	return proto.String(s)
+}
+
+// stringMap приводит карту со значениями произвольного типа к map<string, string>
+func stringMap(value interface{}) map[string]string {
+	switch m := value.(type) {
+	case map[string]string:
+		return m
+	case map[string]interface{}:
+		result := make(map[string]string, len(m))
+		for key, item := range m {
+			if item != nil {
+				result[key] = stringValue(item)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// fieldValue упаковывает значение плоского поля дельты; пустые контейнеры и null дают пустое значение
+func fieldValue(value interface{}) *mtconnectv1.FieldValue {
+	switch v := value.(type) {
+	case string:
+		return &mtconnectv1.FieldValue{StringValue: proto.String(v)}
+	case bool:
+		return &mtconnectv1.FieldValue{BoolValue: proto.Bool(v)}
+	case float64:
+		return &mtconnectv1.FieldValue{NumberValue: proto.Float64(v)}
+	case int:
+		return &mtconnectv1.FieldValue{NumberValue: proto.Float64(float64(v))}
+	case int64:
+		return &mtconnectv1.FieldValue{NumberValue: proto.Float64(float64(v))}
+	}
+	return &mtconnectv1.FieldValue{}
+}
+
+func unixMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
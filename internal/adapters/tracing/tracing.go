@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"MTConnect/internal/buildinfo"
+	"MTConnect/internal/config"
+	"MTConnect/internal/logging"
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.uber.org/fx"
+)
+
+var tracingLog = logging.Logger(logging.ComponentTracing)
+
+// InvokeTracing настраивает глобальные TracerProvider и распространение контекста W3C Trace Context.
+// Сервисы и обработчики получают трассировщик через otel.Tracer, поэтому при выключенной трассировке
+// их спаны ничего не записывают, а заголовок traceparent входящих запросов передается в сообщения без изменений.
+func InvokeTracing(lc fx.Lifecycle, cfg *config.AppConfig) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Tracing.Enabled {
+		return nil
+	}
+
+	var options []otlptracehttp.Option
+	if cfg.Tracing.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpoint(cfg.Tracing.Endpoint))
+	}
+	if cfg.Tracing.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Tracing.Headers) > 0 {
+		options = append(options, otlptracehttp.WithHeaders(cfg.Tracing.Headers))
+	}
+	// Экспортер подключается к коллектору при первой отправке, поэтому недоступный коллектор не мешает запуску
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return fmt.Errorf("не удалось создать экспортер OTLP: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.Tracing.ServiceName),
+		semconv.ServiceVersion(buildinfo.Version),
+	))
+	if err != nil {
+		return fmt.Errorf("не удалось описать ресурс трасс: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(*cfg.Tracing.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		tracingLog.Warn("ошибка экспорта трасс", logging.Err(err))
+	}))
+	tracingLog.Info("трассировка OpenTelemetry включена", "serviceName", cfg.Tracing.ServiceName, "sampleRatio", *cfg.Tracing.SampleRatio)
+
+	// Модуль подключается первым, поэтому останавливается последним и отправляет спаны, завершенные при остановке синков
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return provider.Shutdown(ctx)
+		},
+	})
+	return nil
+}
@@ -0,0 +1,125 @@
+// Package tracingtest содержит коллектор OTLP/HTTP для тестов трассировки
+package tracingtest
+
+import (
+	"MTConnect/internal/adapters/tracing"
+	"MTConnect/internal/config"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/protobuf/proto"
+)
+
+// Collector принимает экспорт трасс по OTLP/HTTP в формате protobuf
+type Collector struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	serviceName string
+	spans       []*tracepb.Span
+}
+
+// NewCollector запускает коллектор, который останавливается по завершении теста
+func NewCollector(t *testing.T) *Collector {
+	t.Helper()
+	collector := &Collector{}
+	collector.server = httptest.NewServer(collector)
+	t.Cleanup(collector.server.Close)
+	return collector
+}
+
+// Start включает трассировку сервиса с экспортом в коллектор и возвращает функцию остановки,
+// которая отправляет коллектору все завершенные спаны. По завершении теста глобальные
+// провайдер и распространение контекста сбрасываются.
+func (c *Collector) Start(t *testing.T, serviceName string) func() {
+	t.Helper()
+	ratio := 1.0
+	cfg := &config.AppConfig{Tracing: config.TracingConfig{
+		Enabled:     true,
+		Endpoint:    strings.TrimPrefix(c.server.URL, "http://"),
+		Insecure:    true,
+		ServiceName: serviceName,
+		SampleRatio: &ratio,
+	}}
+	lifecycle := fxtest.NewLifecycle(t)
+	if err := tracing.InvokeTracing(lifecycle, cfg); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracenoop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	lifecycle.RequireStart()
+	return func() { lifecycle.RequireStop() }
+}
+
+func (c *Collector) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/v1/traces" || req.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var export coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &export); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	for _, resourceSpans := range export.GetResourceSpans() {
+		if name, ok := Attribute(resourceSpans.GetResource().GetAttributes(), "service.name"); ok {
+			c.serviceName = name
+		}
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			c.spans = append(c.spans, scopeSpans.GetSpans()...)
+		}
+	}
+	c.mu.Unlock()
+	response, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(response)
+}
+
+// ServiceName возвращает service.name ресурса из последнего экспорта
+func (c *Collector) ServiceName() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.serviceName
+}
+
+// SpansNamed возвращает принятые спаны с заданным именем
+func (c *Collector) SpansNamed(name string) []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var spans []*tracepb.Span
+	for _, span := range c.spans {
+		if span.GetName() == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Attribute возвращает строковое значение атрибута спана или ресурса
+func Attribute(attributes []*commonpb.KeyValue, key string) (string, bool) {
+	for _, attribute := range attributes {
+		if attribute.GetKey() == key {
+			return attribute.GetValue().GetStringValue(), true
+		}
+	}
+	return "", false
+}
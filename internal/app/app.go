@@ -1,30 +1,46 @@
 package app
 
 import (
+	mtconnectv1 "MTConnect/api/mtconnect/v1"
+	"MTConnect/internal/adapters/grpcapi"
 	"MTConnect/internal/adapters/handlers"
+	"MTConnect/internal/adapters/metrics"
 	"MTConnect/internal/adapters/producers"
 	"MTConnect/internal/adapters/repositories/datastore"
+	"MTConnect/internal/adapters/tracing"
 	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
 	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
 	"MTConnect/internal/services"
 	"MTConnect/internal/usecases"
 	"context"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
 	"time"
 
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 // New создает новый экземпляр fx.App
 func New() *fx.App {
 	return fx.New(
+		fx.WithLogger(newFxLogger),
 		ConfigModule,
+		LoggingModule,
+		TracingModule,
+		MetricsModule,
 		RepositoryModule,
 		ProducerModule,
 		ServiceModule,
 		UsecaseModule,
 		HttpServerModule,
+		GrpcServerModule,
 	)
 }
 
@@ -34,6 +50,20 @@ var ConfigModule = fx.Module("config_module",
 	fx.Provide(config.LoadConfiguration),
 )
 
+var LoggingModule = fx.Module("logging_module",
+	fx.Provide(logging.NewLevelController),
+	fx.Invoke(func(cfg *config.AppConfig) error { return logging.Configure(cfg.Logging) }),
+)
+
+var TracingModule = fx.Module("tracing_module",
+	fx.Invoke(tracing.InvokeTracing),
+)
+
+var MetricsModule = fx.Module("metrics_module",
+	fx.Provide(metrics.NewMetrics, metrics.NewRecorder, metrics.NewStateCollector),
+	fx.Invoke(metrics.RegisterStateCollector),
+)
+
 var RepositoryModule = fx.Module("repository_module",
 	fx.Provide(
 		func(ds interfaces.DataStoreRepository) interfaces.Repository {
@@ -44,7 +74,13 @@ var RepositoryModule = fx.Module("repository_module",
 )
 
 var ProducerModule = fx.Module("producer_module",
-	fx.Provide(producers.NewKafkaProducer),
+	fx.Provide(
+		producers.NewOutboxRegistry,
+		producers.NewOutboxMonitor,
+		producers.NewSinkRegistry,
+		producers.NewDataProducer,
+		producers.NewSinkMonitor,
+	),
 )
 
 var ServiceModule = fx.Module("service_module",
@@ -53,21 +89,44 @@ var ServiceModule = fx.Module("service_module",
 		// Так как они уже возвращают интерфейсы, fx сам всё поймет.
 		services.NewPollingService,
 		services.NewConnectionService,
+		services.NewAlarmService,
+		services.NewStreamHub,
+		services.NewAuthService,
+		services.NewAuditLogService,
 	),
 )
 
 var UsecaseModule = fx.Module("usecases_module",
 	fx.Provide(usecases.NewUsecases),
+	fx.Invoke(InvokeInitialConnections),
 )
 
 var HttpServerModule = fx.Module("http_server_module",
 	fx.Provide(
 		handlers.NewHandler,
+		handlers.NewStreamHandler,
+		handlers.NewAuthMiddleware,
 		handlers.ProvideRouter,
 	),
 	fx.Invoke(InvokeHttpServer, InvokeGracefulShutdown),
 )
 
+var GrpcServerModule = fx.Module("grpc_server_module",
+	fx.Provide(grpcapi.NewServer, grpcapi.NewAuthInterceptor),
+	fx.Invoke(InvokeGrpcServer),
+)
+
+// appLog - журнал запуска и остановки сервиса
+var appLog = logging.Logger(logging.ComponentApp)
+
+// newFxLogger направляет события fx в журнал сервиса. Обычные события пишутся с уровнем debug,
+// чтобы не загромождать журнал при запуске; ошибки fx остаются ошибками.
+func newFxLogger() fxevent.Logger {
+	logger := &fxevent.SlogLogger{Logger: logging.Logger(logging.ComponentFx)}
+	logger.UseLogLevel(slog.LevelDebug)
+	return logger
+}
+
 // InvokeHttpServer запускает HTTP-сервер
 func InvokeHttpServer(lc fx.Lifecycle, cfg *config.AppConfig, h http.Handler) {
 	serverAddr := ":" + cfg.ServerPort
@@ -80,32 +139,115 @@ func InvokeHttpServer(lc fx.Lifecycle, cfg *config.AppConfig, h http.Handler) {
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			log.Printf("Сервер запущен на http://localhost%s", serverAddr)
+			appLog.Info("HTTP-сервер запущен", "addr", "http://localhost"+serverAddr)
 			go func() {
 				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-					log.Fatalf("Не удалось запустить сервер: %v", err)
+					appLog.Error("не удалось запустить HTTP-сервер", logging.Err(err))
+					os.Exit(1)
 				}
 			}()
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			log.Println("Остановка HTTP-сервера...")
+			appLog.Info("остановка HTTP-сервера")
 			return server.Shutdown(ctx)
 		},
 	})
 }
 
+// InvokeGrpcServer запускает gRPC-сервер, если задан grpc_port
+func InvokeGrpcServer(lc fx.Lifecycle, cfg *config.AppConfig, srv *grpcapi.Server, auth *grpcapi.AuthInterceptor) {
+	if cfg.GrpcPort == "" {
+		return
+	}
+	serverAddr := ":" + cfg.GrpcPort
+	heartbeat := time.Duration(cfg.Stream.HeartbeatSec) * time.Second
+	server := grpc.NewServer(
+		// Keepalive заменяет heartbeat потоков SSE и WebSocket: обрыв соединения обнаруживается без событий
+		grpc.KeepaliveParams(keepalive.ServerParameters{Time: heartbeat, Timeout: heartbeat}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: 5 * time.Second, PermitWithoutStream: true}),
+		grpc.UnaryInterceptor(auth.Unary()),
+		grpc.StreamInterceptor(auth.Stream()),
+	)
+	mtconnectv1.RegisterStreamerServiceServer(server, srv)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", serverAddr)
+			if err != nil {
+				return err
+			}
+			appLog.Info("gRPC-сервер запущен", "addr", listener.Addr().String())
+			go func() {
+				if err := server.Serve(listener); err != nil {
+					appLog.Error("не удалось запустить gRPC-сервер", logging.Err(err))
+					os.Exit(1)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			appLog.Info("остановка gRPC-сервера")
+			srv.Close()
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				server.Stop()
+			}
+			return nil
+		},
+	})
+}
+
+// InvokeInitialConnections создает подключения из раздела connections конфигурации. Агенты опрашиваются
+// в фоне, чтобы недоступный агент не задерживал запуск; ошибки отдельных подключений только логируются.
+func InvokeInitialConnections(lc fx.Lifecycle, cfg *config.AppConfig, usecase interfaces.Usecases) {
+	if len(cfg.Connections) == 0 {
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				results, err := usecase.BulkCreateConnections(entities.BulkConnectionRequest{Connections: cfg.Connections})
+				if err != nil {
+					appLog.Error("не удалось создать подключения из конфигурации", logging.Err(err))
+					return
+				}
+				created := 0
+				for _, result := range results {
+					if result.Connection == nil {
+						appLog.Warn("подключение из конфигурации не создано", "index", result.Index, logging.KeyEndpoint, result.Request.EndpointURL,
+							"model", result.Request.Model, logging.KeyError, result.Message)
+						continue
+					}
+					created++
+				}
+				appLog.Info("созданы подключения из конфигурации", "created", created, "total", len(results))
+			}()
+			return nil
+		},
+	})
+}
+
 // InvokeGracefulShutdown обеспечивает корректное завершение работы сервисов
-func InvokeGracefulShutdown(lc fx.Lifecycle, poller interfaces.PollingService, producer interfaces.DataProducer) {
+func InvokeGracefulShutdown(lc fx.Lifecycle, poller interfaces.PollingService, producer interfaces.DataProducer, audit interfaces.AuditLog) {
 	lc.Append(fx.Hook{
 		OnStop: func(ctx context.Context) error {
-			log.Println("Корректное завершение работы сервисов...")
+			appLog.Info("корректное завершение работы сервисов")
 			poller.StopAllPolling()
 			if err := producer.Close(); err != nil {
-				log.Printf("Ошибка при закрытии Kafka продюсера: %v", err)
+				appLog.Error("ошибка при закрытии синков публикации", logging.Err(err))
 				return err
 			}
-			log.Println("Все сервисы успешно остановлены.")
+			if err := audit.Close(); err != nil {
+				appLog.Error("ошибка при закрытии журнала аудита", logging.Err(err))
+			}
+			appLog.Info("все сервисы остановлены")
 			return nil
 		},
 	})
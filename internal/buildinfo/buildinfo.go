@@ -0,0 +1,9 @@
+// Package buildinfo содержит сведения о сборке сервиса
+package buildinfo
+
+// ServiceName - имя сервиса в метаданных публикуемых сообщений
+const ServiceName = "mtconnect-streamer"
+
+// Version - версия сервиса, задается при сборке:
+// go build -ldflags "-X MTConnect/internal/buildinfo.Version=1.2.3"
+var Version = "dev"
@@ -1,15 +1,412 @@
 package config
 
 import (
+	"MTConnect/internal/buildinfo"
+	"MTConnect/internal/domain/entities"
 	"encoding/json"
 	"os"
 )
 
 // AppConfig содержит конфигурацию приложения
 type AppConfig struct {
-	ServerPort   string   `json:"server_port"`
+	ServerPort string `json:"server_port"`
+	// GrpcPort - порт gRPC API; пустое значение отключает gRPC-сервер
+	GrpcPort string `json:"grpc_port"`
+
+	// Параметры Kafka верхнего уровня используются, если список sinks не задан:
+	// в этом случае из них формируется единственный синк "kafka"
 	KafkaBrokers []string `json:"kafka_brokers"`
 	KafkaTopic   string   `json:"kafka_topic"`
+	// KafkaAlarmTopic - топик для событий жизненного цикла аварий
+	KafkaAlarmTopic string `json:"kafka_alarm_topic"`
+	// KafkaTopics, KafkaRoutes и KafkaAutoCreateTopics - маршрутизация по топикам, см. KafkaSinkConfig
+	KafkaTopics           map[string]string       `json:"kafka_topics"`
+	KafkaRoutes           []KafkaRouteConfig      `json:"kafka_routes"`
+	KafkaAutoCreateTopics *KafkaTopicCreateConfig `json:"kafka_auto_create_topics"`
+	// KafkaRequiredAcks - подтверждения записи: "none", "one" или "all"
+	KafkaRequiredAcks string `json:"kafka_required_acks"`
+	// KafkaCompression - кодек сжатия: "none", "gzip", "snappy", "lz4" или "zstd"
+	KafkaCompression string `json:"kafka_compression"`
+	// KafkaBatchSize - максимальное количество сообщений в пакете
+	KafkaBatchSize int `json:"kafka_batch_size"`
+	// KafkaBatchTimeoutMs - максимальное время накопления пакета
+	KafkaBatchTimeoutMs int `json:"kafka_batch_timeout_ms"`
+	// KafkaAsync включает асинхронную отправку, ошибки доставки передаются в лог
+	KafkaAsync bool            `json:"kafka_async"`
+	KafkaTLS   TLSConfig       `json:"kafka_tls"`
+	KafkaSASL  KafkaSASLConfig `json:"kafka_sasl"`
+
+	// Sinks - список синков публикации, работающих одновременно
+	Sinks []SinkConfig `json:"sinks"`
+	// Outbox - локальная дисковая очередь сообщений на время недоступности синков
+	Outbox OutboxConfig `json:"outbox"`
+	// Publish управляет режимом публикации снимков MachineData
+	Publish PublishConfig `json:"publish"`
+	// Observations включает публикацию исходных наблюдений MTConnect
+	Observations ObservationsConfig `json:"observations"`
+	// Stream - параметры потоков реального времени (SSE и WebSocket)
+	Stream StreamConfig `json:"stream"`
+	// Auth - аутентификация клиентов REST и gRPC API и журнал аудита
+	Auth AuthConfig `json:"auth"`
+	// Connections - подключения, создаваемые при запуске; формат совпадает с GET /api/v1/connections:export
+	Connections []entities.ConnectionRequest `json:"connections"`
+	// Metrics - экспорт показателей в формате Prometheus
+	Metrics MetricsConfig `json:"metrics"`
+	// Health - пороги проверки готовности /readyz
+	Health HealthConfig `json:"health"`
+	// Tracing - экспорт трасс OpenTelemetry
+	Tracing TracingConfig `json:"tracing"`
+	// Logging - уровни и формат журнала сервиса
+	Logging LoggingConfig `json:"logging"`
+}
+
+// Типы синков публикации
+const (
+	SinkTypeKafka   = "kafka"
+	SinkTypeMQTT    = "mqtt"
+	SinkTypeWebhook = "webhook"
+	SinkTypeFile    = "file"
+)
+
+// Режимы CloudEvents
+const (
+	CloudEventsBinary     = "binary"
+	CloudEventsStructured = "structured"
+	CloudEventsNone       = "none"
+)
+
+// SinkConfig описывает один синк публикации
+type SinkConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Enabled по умолчанию true
+	Enabled *bool `json:"enabled"`
+	// Encoding - формат сериализации сообщений синка: json (по умолчанию), protobuf или avro
+	Encoding string `json:"encoding"`
+	// SchemaRegistry включает формат Confluent (magic byte + идентификатор схемы) для protobuf и avro
+	SchemaRegistry *SchemaRegistryConfig `json:"schema_registry,omitempty"`
+	// CloudEvents - режим CloudEvents 1.0: binary (атрибуты в заголовках, по умолчанию),
+	// structured (сообщение оборачивается в JSON-конверт) или none
+	CloudEvents string     `json:"cloudevents"`
+	Filter      SinkFilter `json:"filter"`
+	// QueueSize - размер очереди синка; при переполнении новые сообщения отбрасываются,
+	// чтобы медленный синк не блокировал цикл опроса
+	QueueSize int `json:"queue_size"`
+	// Outbox включает дисковую очередь для синка (по умолчанию outbox.enabled)
+	Outbox *bool `json:"outbox"`
+
+	Kafka   *KafkaSinkConfig   `json:"kafka,omitempty"`
+	MQTT    *MQTTSinkConfig    `json:"mqtt,omitempty"`
+	Webhook *WebhookSinkConfig `json:"webhook,omitempty"`
+	File    *FileSinkConfig    `json:"file,omitempty"`
+}
+
+// IsEnabled сообщает, включен ли синк
+func (s SinkConfig) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// SinkFilter ограничивает сообщения, попадающие в синк. Пустой список означает "все".
+type SinkFilter struct {
+	// Machines - идентификаторы станков
+	Machines []string `json:"machines"`
+	// Fields - поля MachineData верхнего уровня для снимков и дельт
+	Fields []string `json:"fields"`
+	// MessageTypes - типы сообщений: "snapshot", "delta", "alarm", "lifecycle", "observation"
+	MessageTypes []string `json:"message_types"`
+}
+
+// KafkaSinkConfig содержит настройки синка Kafka
+type KafkaSinkConfig struct {
+	Brokers []string `json:"brokers"`
+	// Topic - топик снимков состояния, сокращение для Topics["snapshot"]
+	Topic string `json:"topic"`
+	// AlarmTopic - сокращение для Topics["alarm"]
+	AlarmTopic string `json:"alarm_topic"`
+	// Topics - шаблоны топиков по типам сообщений (snapshot, delta, alarm, lifecycle, observation).
+	// Подстановки: {manufacturer}, {machineId}, {sessionId}, {type}
+	Topics map[string]string `json:"topics"`
+	// Routes - правила маршрутизации отдельных станков, проверяются по порядку до первого совпадения
+	Routes []KafkaRouteConfig `json:"routes"`
+	// AutoCreateTopics - создание топиков с заданными параметрами перед первой отправкой
+	AutoCreateTopics *KafkaTopicCreateConfig `json:"auto_create_topics"`
+	RequiredAcks     string                  `json:"required_acks"`
+	Compression      string                  `json:"compression"`
+	BatchSize        int                     `json:"batch_size"`
+	BatchTimeoutMs   int                     `json:"batch_timeout_ms"`
+	Async            bool                    `json:"async"`
+	TLS              TLSConfig               `json:"tls"`
+	SASL             KafkaSASLConfig         `json:"sasl"`
+}
+
+// KafkaRouteConfig направляет сообщения выбранных станков в отдельный топик.
+// Пустой список условия не ограничивает; MachineIDs и Manufacturers допускают шаблоны вида "VTC-*".
+type KafkaRouteConfig struct {
+	MachineIDs    []string `json:"machine_ids"`
+	Manufacturers []string `json:"manufacturers"`
+	MessageTypes  []string `json:"message_types"`
+	// Topic - шаблон топика с теми же подстановками, что и в Topics
+	Topic string `json:"topic"`
+}
+
+// KafkaTopicCreateConfig задает параметры топиков, создаваемых сервисом.
+// Если брокер запрещает создание топиков, сообщения отправляются в существующие топики.
+type KafkaTopicCreateConfig struct {
+	Enabled           bool `json:"enabled"`
+	Partitions        int  `json:"partitions"`
+	ReplicationFactor int  `json:"replication_factor"`
+	// RetentionHours - срок хранения сообщений (retention.ms); 0 - значение брокера
+	RetentionHours int `json:"retention_hours"`
+	// Configs - дополнительные параметры топика, например {"cleanup.policy": "compact"}
+	Configs map[string]string `json:"configs"`
+}
+
+// MQTTSinkConfig содержит настройки синка MQTT
+type MQTTSinkConfig struct {
+	// BrokerURL - адрес брокера, например tcp://localhost:1883 или ssl://broker:8883
+	BrokerURL string `json:"broker_url"`
+	ClientID  string `json:"client_id"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	// ProtocolVersion - "3.1.1" (по умолчанию) или "5"
+	ProtocolVersion string `json:"protocol_version"`
+	QoS             byte   `json:"qos"`
+	// RetainState сохраняет на брокере последнее состояние станка (по умолчанию true)
+	RetainState *bool `json:"retain_state"`
+	// Topics - шаблоны топиков по типам сообщений. Подстановки: {manufacturer}, {machineId}, {sessionId}, {type}
+	Topics map[string]string `json:"topics"`
+	// StatusTopic - топик доступности сервиса: "online" при подключении, "offline" как last will
+	StatusTopic  string    `json:"status_topic"`
+	KeepAliveSec int       `json:"keep_alive_sec"`
+	TLS          TLSConfig `json:"tls"`
+}
+
+// WebhookSinkConfig содержит настройки синка HTTP webhook
+type WebhookSinkConfig struct {
+	URLs []string `json:"urls"`
+	// Secret - ключ для подписи тела запроса HMAC-SHA256; пустое значение отключает подпись
+	Secret  string            `json:"secret"`
+	Headers map[string]string `json:"headers"`
+	// TimeoutMs - таймаут одного HTTP-запроса
+	TimeoutMs int `json:"timeout_ms"`
+	// MaxRetries - количество повторов после неудачной попытки; отрицательное значение отключает повторы
+	MaxRetries int `json:"max_retries"`
+	// RetryBackoffMs - начальная задержка повтора, удваивается с каждой попыткой
+	RetryBackoffMs int `json:"retry_backoff_ms"`
+	// BatchSize - количество сообщений в одном запросе; при значении больше 1 тело - JSON-массив
+	BatchSize       int `json:"batch_size"`
+	BatchIntervalMs int `json:"batch_interval_ms"`
+	// PauseAfterFailures - число подряд неудачных доставок, после которого адрес приостанавливается
+	PauseAfterFailures int `json:"pause_after_failures"`
+	PauseDurationSec   int `json:"pause_duration_sec"`
+}
+
+// FileSinkConfig содержит настройки синка локального архива
+type FileSinkConfig struct {
+	Dir string `json:"dir"`
+	// Format - формат файлов: ndjson или csv (плоские поля)
+	Format string `json:"format"`
+	// PathTemplate - подкаталог станка, поддерживает {manufacturer}, {machineId}, {sessionId}, {type}
+	PathTemplate string `json:"path_template"`
+	// RotateMaxMB - максимальный размер файла до ротации
+	RotateMaxMB int `json:"rotate_max_mb"`
+	// RotateHourly - начинать новый файл в начале каждого часа (UTC)
+	RotateHourly *bool `json:"rotate_hourly,omitempty"`
+	Gzip         bool  `json:"gzip"`
+	// RetentionHours - файлы старше этого срока удаляются; отрицательное значение отключает удаление по возрасту
+	RetentionHours int `json:"retention_hours"`
+	// RetentionMaxMB - предельный суммарный размер архива; 0 отключает ограничение
+	RetentionMaxMB int `json:"retention_max_mb"`
+}
+
+// SchemaRegistryConfig содержит настройки подключения к Confluent Schema Registry.
+// Схемы регистрируются под именем записи (RecordNameStrategy), например mtconnect.v1.MachineData.
+type SchemaRegistryConfig struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// AutoRegister - регистрировать схему при первой отправке (по умолчанию true);
+	// при false схема должна быть заранее зарегистрирована
+	AutoRegister *bool     `json:"auto_register,omitempty"`
+	TLS          TLSConfig `json:"tls"`
+}
+
+// TLSConfig содержит настройки TLS для подключения к брокерам (Kafka, MQTT)
+type TLSConfig struct {
+	Enabled            bool   `json:"enabled"`
+	CAFile             string `json:"ca_file"`
+	CertFile           string `json:"cert_file"`
+	KeyFile            string `json:"key_file"`
+	ServerName         string `json:"server_name"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+// KafkaSASLConfig содержит настройки SASL-аутентификации в Kafka
+type KafkaSASLConfig struct {
+	// Mechanism - "plain", "scram-sha-256" или "scram-sha-512"; пустое значение отключает SASL
+	Mechanism string `json:"mechanism"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+}
+
+// OutboxConfig содержит настройки дисковой очереди неотправленных сообщений
+type OutboxConfig struct {
+	// Enabled - значение по умолчанию для синков; для синка Kafka из параметров kafka_* верхнего уровня
+	// очередь включена, если не выключена явно
+	Enabled *bool  `json:"enabled"`
+	Dir     string `json:"dir"`
+	// MaxBytes - лимит размера очереди; при превышении вытесняются самые старые сообщения
+	MaxBytes        int64 `json:"max_bytes"`
+	RetryIntervalMs int   `json:"retry_interval_ms"`
+}
+
+// Режимы публикации MachineData
+const (
+	PublishModeAlways   = "always"
+	PublishModeOnChange = "on_change"
+)
+
+// PublishConfig содержит настройки публикации только изменившихся данных
+type PublishConfig struct {
+	// Mode - "always" (каждый цикл опроса) или "on_change" (только при изменениях)
+	Mode string `json:"mode"`
+	// KeyframeIntervalSec - период отправки полного состояния в режиме on_change
+	KeyframeIntervalSec int `json:"keyframe_interval_sec"`
+	// Deltas включает публикацию только изменившихся полей вместо всего документа
+	Deltas bool `json:"deltas"`
+	// Deadbands - зоны нечувствительности для числовых значений.
+	// Ключ - полный путь поля ("FeedRate.VALUE"), поле верхнего уровня ("FeedRate"),
+	// тип данных ("position") или "*" для значения по умолчанию.
+	Deadbands map[string]float64 `json:"deadbands"`
+}
+
+// Источники исходных наблюдений
+const (
+	ObservationsIngestCurrent = "current"
+	ObservationsIngestSample  = "sample"
+)
+
+// ObservationsConfig содержит настройки публикации исходных наблюдений (по сообщению на изменение DataItem)
+type ObservationsConfig struct {
+	Enabled bool `json:"enabled"`
+	// Ingest - "current": изменения между опросами /current (промежуточные значения теряются),
+	// "sample": все наблюдения из /sample начиная с последней прочитанной последовательности
+	Ingest string `json:"ingest"`
+	// SampleCount - максимальное количество наблюдений в одном запросе /sample
+	SampleCount int `json:"sample_count"`
+}
+
+// StreamConfig содержит ограничения потоков реального времени для веб-клиентов
+type StreamConfig struct {
+	// BufferSize - очередь событий клиента; клиент, не успевающий ее разбирать, отключается
+	BufferSize int `json:"buffer_size"`
+	// HistorySize - количество последних событий, доступных для возобновления по Last-Event-ID
+	HistorySize int `json:"history_size"`
+	// MaxClients - максимальное количество одновременных подписчиков
+	MaxClients   int `json:"max_clients"`
+	HeartbeatSec int `json:"heartbeat_sec"`
+	// AllowedOrigins - источники, с которых разрешено подключение по WebSocket;
+	// пустой список разрешает только тот же источник, "*" - любой
+	AllowedOrigins []string `json:"allowed_origins"`
+}
+
+// MetricsConfig описывает эндпоинт показателей Prometheus
+type MetricsConfig struct {
+	// Enabled по умолчанию true
+	Enabled *bool `json:"enabled"`
+	// Path - путь эндпоинта (по умолчанию /metrics)
+	Path string `json:"path"`
+	// RequireAuth требует роль viewer; по умолчанию эндпоинт доступен без аутентификации
+	RequireAuth bool `json:"require_auth"`
+}
+
+// IsEnabled сообщает, включен ли эндпоинт показателей
+func (m MetricsConfig) IsEnabled() bool {
+	return m.Enabled == nil || *m.Enabled
+}
+
+// HealthConfig задает пороги проверки готовности сервиса
+type HealthConfig struct {
+	// StaleIntervals - число интервалов опроса без данных, после которого сессия считается зависшей (по умолчанию 3)
+	StaleIntervals int `json:"stale_intervals"`
+	// MinHealthyRatio - минимальная доля исправных сессий (по умолчанию 0.5, 0 отключает проверку)
+	MinHealthyRatio *float64 `json:"min_healthy_ratio"`
+	// MaxStaleRatio - максимальная доля зависших сессий среди опрашиваемых (по умолчанию 0.5, 1 отключает проверку)
+	MaxStaleRatio *float64 `json:"max_stale_ratio"`
+	// CheckTimeoutMs - время ожидания проверки связи синка с брокером (по умолчанию 2000)
+	CheckTimeoutMs int `json:"check_timeout_ms"`
+}
+
+// TracingConfig описывает экспорт трасс OpenTelemetry по OTLP/HTTP
+type TracingConfig struct {
+	Enabled bool `json:"enabled"`
+	// Endpoint - адрес коллектора host:port; если не задан, используется OTEL_EXPORTER_OTLP_ENDPOINT или localhost:4318
+	Endpoint string `json:"endpoint"`
+	// Insecure отправляет трассы по HTTP без TLS
+	Insecure bool `json:"insecure"`
+	// Headers - дополнительные заголовки запросов к коллектору, например для аутентификации
+	Headers map[string]string `json:"headers"`
+	// ServiceName - имя сервиса в ресурсе трасс (по умолчанию mtconnect-streamer)
+	ServiceName string `json:"service_name"`
+	// SampleRatio - доля записываемых трасс без родителя (по умолчанию 1); трассы входящих запросов следуют решению родителя
+	SampleRatio *float64 `json:"sample_ratio"`
+}
+
+// Форматы журнала сервиса
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// LoggingConfig описывает журнал сервиса
+type LoggingConfig struct {
+	// Level - уровень по умолчанию: debug, info (по умолчанию), warn, error
+	Level string `json:"level"`
+	// Format - json (по умолчанию) или text
+	Format string `json:"format"`
+	// Components - уровни отдельных компонентов, например {"poller": "debug"}
+	Components map[string]string `json:"components"`
+	// RepeatIntervalSec - период, в течение которого повторяющаяся ошибка опроса сессии пишется один раз
+	// (по умолчанию 60, отрицательное значение отключает подавление)
+	RepeatIntervalSec int `json:"repeat_interval_sec"`
+}
+
+// AuthConfig описывает аутентификацию по API-ключам и JWT.
+// Роли: viewer - чтение, operator - управление опросом, admin - управление подключениями.
+type AuthConfig struct {
+	// Enabled включает проверку ключей и токенов; без нее все запросы выполняются с ролью admin
+	Enabled bool           `json:"enabled"`
+	APIKeys []APIKeyConfig `json:"api_keys"`
+	// KeysFile - JSON-файл со списком ключей в формате api_keys, дополняющий список из конфигурации
+	KeysFile string    `json:"keys_file"`
+	JWT      JWTConfig `json:"jwt"`
+	// AuditLog - файл журнала аудита в формате JSON Lines (по умолчанию data/audit.log)
+	AuditLog string `json:"audit_log"`
+}
+
+// APIKeyConfig - ключ клиента API; передается в заголовке X-API-Key
+type APIKeyConfig struct {
+	// Name - владелец ключа, записываемый в журнал аудита
+	Name string `json:"name"`
+	// Key - ключ в открытом виде; KeySHA256 - SHA-256 ключа в hex, чтобы не хранить сам ключ
+	Key       string `json:"key"`
+	KeySHA256 string `json:"key_sha256"`
+	Role      string `json:"role"`
+}
+
+// JWTConfig описывает локальную проверку JWT из заголовка Authorization: Bearer
+type JWTConfig struct {
+	// Secret - общий секрет для HS256, HS384 и HS512
+	Secret string `json:"secret"`
+	// PublicKeyFile - PEM-файл открытого ключа RSA, ECDSA или Ed25519 для RS*, PS*, ES* и EdDSA
+	PublicKeyFile string `json:"public_key_file"`
+	// Issuer и Audience проверяются, если заданы
+	Issuer   string `json:"issuer"`
+	Audience string `json:"audience"`
+	// RoleClaim - claim с ролью: строка или массив строк (по умолчанию "role")
+	RoleClaim string `json:"role_claim"`
+	// LeewaySec - допустимое расхождение часов при проверке exp и nbf
+	LeewaySec int `json:"leeway_sec"`
 }
 
 // LoadConfiguration загружает конфигурацию из файла
@@ -25,5 +422,279 @@ func LoadConfiguration() (*AppConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	config.applyDefaults()
 	return &config, nil
 }
+
+// applyDefaults заполняет значения по умолчанию
+func (c *AppConfig) applyDefaults() {
+	if c.KafkaAlarmTopic == "" {
+		c.KafkaAlarmTopic = "mtconnect_alarms"
+	}
+	if c.KafkaRequiredAcks == "" {
+		c.KafkaRequiredAcks = "one"
+	}
+	if len(c.Sinks) == 0 {
+		c.Sinks = []SinkConfig{c.legacyKafkaSink()}
+	}
+	for i := range c.Sinks {
+		sink := &c.Sinks[i]
+		if sink.Name == "" {
+			sink.Name = sink.Type
+		}
+		if sink.Encoding == "" {
+			sink.Encoding = "json"
+		}
+		if sink.CloudEvents == "" {
+			sink.CloudEvents = CloudEventsBinary
+		}
+		if sink.QueueSize <= 0 {
+			sink.QueueSize = 1000
+		}
+		if sink.Outbox == nil {
+			enabled := c.Outbox.Enabled != nil && *c.Outbox.Enabled
+			sink.Outbox = &enabled
+		}
+		if sink.MQTT != nil {
+			sink.MQTT.applyDefaults()
+		}
+		if sink.SchemaRegistry != nil && sink.SchemaRegistry.AutoRegister == nil {
+			autoRegister := true
+			sink.SchemaRegistry.AutoRegister = &autoRegister
+		}
+		if sink.File != nil {
+			sink.File.applyDefaults()
+		}
+		if sink.Webhook != nil {
+			sink.Webhook.applyDefaults()
+		}
+		if sink.Kafka != nil {
+			sink.Kafka.applyDefaults()
+		}
+	}
+	if c.Outbox.Dir == "" {
+		c.Outbox.Dir = "data/outbox"
+	}
+	if c.Outbox.MaxBytes <= 0 {
+		c.Outbox.MaxBytes = 256 << 20
+	}
+	if c.Outbox.RetryIntervalMs <= 0 {
+		c.Outbox.RetryIntervalMs = 5000
+	}
+	if c.Publish.Mode == "" {
+		c.Publish.Mode = PublishModeAlways
+	}
+	if c.Publish.KeyframeIntervalSec <= 0 {
+		c.Publish.KeyframeIntervalSec = 60
+	}
+	if c.Observations.Ingest == "" {
+		c.Observations.Ingest = ObservationsIngestCurrent
+	}
+	if c.Observations.SampleCount <= 0 {
+		c.Observations.SampleCount = 1000
+	}
+	if c.Stream.BufferSize <= 0 {
+		c.Stream.BufferSize = 256
+	}
+	if c.Stream.HistorySize <= 0 {
+		c.Stream.HistorySize = 1000
+	}
+	if c.Stream.MaxClients <= 0 {
+		c.Stream.MaxClients = 100
+	}
+	if c.Stream.HeartbeatSec <= 0 {
+		c.Stream.HeartbeatSec = 15
+	}
+	if c.Auth.JWT.RoleClaim == "" {
+		c.Auth.JWT.RoleClaim = "role"
+	}
+	if c.Auth.AuditLog == "" {
+		c.Auth.AuditLog = "data/audit.log"
+	}
+	if c.Metrics.Path == "" {
+		c.Metrics.Path = "/metrics"
+	}
+	if c.Health.StaleIntervals <= 0 {
+		c.Health.StaleIntervals = 3
+	}
+	if c.Health.MinHealthyRatio == nil {
+		ratio := 0.5
+		c.Health.MinHealthyRatio = &ratio
+	}
+	if c.Health.MaxStaleRatio == nil {
+		ratio := 0.5
+		c.Health.MaxStaleRatio = &ratio
+	}
+	if c.Health.CheckTimeoutMs <= 0 {
+		c.Health.CheckTimeoutMs = 2000
+	}
+	if c.Logging.Level == "" {
+		c.Logging.Level = "info"
+	}
+	if c.Logging.Format == "" {
+		c.Logging.Format = LogFormatJSON
+	}
+	if c.Logging.RepeatIntervalSec == 0 {
+		c.Logging.RepeatIntervalSec = 60
+	}
+	if c.Tracing.ServiceName == "" {
+		c.Tracing.ServiceName = buildinfo.ServiceName
+	}
+	if c.Tracing.SampleRatio == nil {
+		ratio := 1.0
+		c.Tracing.SampleRatio = &ratio
+	}
+}
+
+// legacyKafkaSink формирует синк Kafka из параметров kafka_* верхнего уровня
+func (c *AppConfig) legacyKafkaSink() SinkConfig {
+	// Единственный синк без дисковой очереди терял бы сообщения при недоступности брокера
+	outbox := c.Outbox.Enabled == nil || *c.Outbox.Enabled
+	return SinkConfig{
+		Name:   SinkTypeKafka,
+		Type:   SinkTypeKafka,
+		Outbox: &outbox,
+		Kafka: &KafkaSinkConfig{
+			Brokers:          c.KafkaBrokers,
+			Topic:            c.KafkaTopic,
+			AlarmTopic:       c.KafkaAlarmTopic,
+			Topics:           c.KafkaTopics,
+			Routes:           c.KafkaRoutes,
+			AutoCreateTopics: c.KafkaAutoCreateTopics,
+			RequiredAcks:     c.KafkaRequiredAcks,
+			Compression:      c.KafkaCompression,
+			BatchSize:        c.KafkaBatchSize,
+			BatchTimeoutMs:   c.KafkaBatchTimeoutMs,
+			Async:            c.KafkaAsync,
+			TLS:              c.KafkaTLS,
+			SASL:             c.KafkaSASL,
+		},
+	}
+}
+
+// applyDefaults заполняет значения по умолчанию для синка Kafka
+func (k *KafkaSinkConfig) applyDefaults() {
+	if k.RequiredAcks == "" {
+		k.RequiredAcks = "one"
+	}
+	if k.Topics == nil {
+		k.Topics = make(map[string]string)
+	}
+	if _, ok := k.Topics["snapshot"]; !ok && k.Topic != "" {
+		k.Topics["snapshot"] = k.Topic
+	}
+	if _, ok := k.Topics["alarm"]; !ok && k.AlarmTopic != "" {
+		k.Topics["alarm"] = k.AlarmTopic
+	}
+	// Каждый тип сообщений по умолчанию пишется в свой топик, чтобы потребители снимков
+	// не получали сообщения другой структуры
+	defaultTopics := map[string]string{
+		"snapshot":    "mtconnect_data",
+		"delta":       "mtconnect_deltas",
+		"alarm":       "mtconnect_alarms",
+		"lifecycle":   "mtconnect_lifecycle",
+		"observation": "mtconnect_observations",
+	}
+	for messageType, topic := range defaultTopics {
+		if _, ok := k.Topics[messageType]; !ok {
+			k.Topics[messageType] = topic
+		}
+	}
+	if create := k.AutoCreateTopics; create != nil {
+		if create.Partitions <= 0 {
+			create.Partitions = 1
+		}
+		if create.ReplicationFactor <= 0 {
+			create.ReplicationFactor = 1
+		}
+	}
+}
+
+// applyDefaults заполняет значения по умолчанию для синка MQTT
+func (m *MQTTSinkConfig) applyDefaults() {
+	if m.ProtocolVersion == "" {
+		m.ProtocolVersion = "3.1.1"
+	}
+	if m.ClientID == "" {
+		hostname, _ := os.Hostname()
+		m.ClientID = "mtconnect-streamer-" + hostname
+	}
+	if m.RetainState == nil {
+		retain := true
+		m.RetainState = &retain
+	}
+	defaultTopics := map[string]string{
+		"snapshot":  "mtconnect/{manufacturer}/{machineId}/state",
+		"delta":     "mtconnect/{manufacturer}/{machineId}/delta",
+		"alarm":     "mtconnect/{manufacturer}/{machineId}/alarms",
+		"lifecycle": "mtconnect/{manufacturer}/{machineId}/lifecycle",
+	}
+	if m.Topics == nil {
+		m.Topics = make(map[string]string)
+	}
+	for messageType, topic := range defaultTopics {
+		if _, ok := m.Topics[messageType]; !ok {
+			m.Topics[messageType] = topic
+		}
+	}
+	if m.StatusTopic == "" {
+		m.StatusTopic = "mtconnect/_service/" + m.ClientID + "/status"
+	}
+	if m.KeepAliveSec <= 0 {
+		m.KeepAliveSec = 30
+	}
+}
+
+// applyDefaults заполняет значения по умолчанию для синка webhook
+func (w *WebhookSinkConfig) applyDefaults() {
+	if w.TimeoutMs <= 0 {
+		w.TimeoutMs = 10000
+	}
+	switch {
+	case w.MaxRetries == 0:
+		w.MaxRetries = 3
+	case w.MaxRetries < 0:
+		w.MaxRetries = 0
+	}
+	if w.RetryBackoffMs <= 0 {
+		w.RetryBackoffMs = 500
+	}
+	if w.BatchSize <= 0 {
+		w.BatchSize = 1
+	}
+	if w.BatchIntervalMs <= 0 {
+		w.BatchIntervalMs = 1000
+	}
+	if w.PauseAfterFailures <= 0 {
+		w.PauseAfterFailures = 5
+	}
+	if w.PauseDurationSec <= 0 {
+		w.PauseDurationSec = 60
+	}
+}
+
+// applyDefaults заполняет значения по умолчанию для синка локального архива
+func (f *FileSinkConfig) applyDefaults() {
+	if f.Dir == "" {
+		f.Dir = "data/archive"
+	}
+	if f.Format == "" {
+		f.Format = "ndjson"
+	}
+	if f.PathTemplate == "" {
+		f.PathTemplate = "{machineId}"
+	}
+	if f.RotateMaxMB <= 0 {
+		f.RotateMaxMB = 64
+	}
+	if f.RotateHourly == nil {
+		rotateHourly := true
+		f.RotateHourly = &rotateHourly
+	}
+	switch {
+	case f.RetentionHours == 0:
+		f.RetentionHours = 168
+	case f.RetentionHours < 0:
+		f.RetentionHours = 0
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import "testing"
+
+func TestKafkaSinkDefaultTopicsPerMessageType(t *testing.T) {
+	kafka := &KafkaSinkConfig{Topic: "plant_data", Topics: map[string]string{"lifecycle": "plant_lifecycle"}}
+	kafka.applyDefaults()
+	want := map[string]string{
+		"snapshot":    "plant_data",
+		"delta":       "mtconnect_deltas",
+		"alarm":       "mtconnect_alarms",
+		"lifecycle":   "plant_lifecycle",
+		"observation": "mtconnect_observations",
+	}
+	for messageType, topic := range want {
+		if kafka.Topics[messageType] != topic {
+			t.Errorf("топик %s = '%s', ожидался '%s'", messageType, kafka.Topics[messageType], topic)
+		}
+	}
+}
+
+func TestLegacyKafkaSinkOutboxEnabledByDefault(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name   string
+		config AppConfig
+		want   bool
+	}{
+		{"kafka_* без outbox.enabled", AppConfig{KafkaBrokers: []string{"localhost:9092"}, KafkaTopic: "mtconnect_data"}, true},
+		{"outbox.enabled выключен явно", AppConfig{KafkaBrokers: []string{"localhost:9092"}, Outbox: OutboxConfig{Enabled: &disabled}}, false},
+		{"синк из sinks", AppConfig{Sinks: []SinkConfig{{Type: SinkTypeKafka, Kafka: &KafkaSinkConfig{Brokers: []string{"localhost:9092"}}}}}, false},
+	}
+	for _, test := range tests {
+		test.config.applyDefaults()
+		if got := *test.config.Sinks[0].Outbox; got != test.want {
+			t.Errorf("%s: outbox = %v, ожидалось %v", test.name, got, test.want)
+		}
+	}
+}
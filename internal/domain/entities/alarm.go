@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+// Типы событий жизненного цикла аварий
+const (
+	AlarmRaised  = "AlarmRaised"
+	AlarmUpdated = "AlarmUpdated"
+	AlarmCleared = "AlarmCleared"
+)
+
+// ActiveAlarm описывает аварию или предупреждение, активное в данный момент
+type ActiveAlarm struct {
+	SessionID      string    `json:"sessionId"`
+	MachineId      string    `json:"machineId"`
+	DataItemId     string    `json:"dataItemId"`
+	NativeCode     string    `json:"nativeCode,omitempty"`
+	NativeSeverity string    `json:"nativeSeverity,omitempty"`
+	Qualifier      string    `json:"qualifier,omitempty"`
+	Level          string    `json:"level"`
+	Type           string    `json:"type,omitempty"`
+	ComponentId    string    `json:"componentId,omitempty"`
+	ComponentName  string    `json:"componentName,omitempty"`
+	Message        string    `json:"message,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	LastUpdated    time.Time `json:"lastUpdated"`
+}
+
+// AlarmEvent - дискретное событие жизненного цикла аварии, отправляемое во внешние системы
+type AlarmEvent struct {
+	EventType      string     `json:"eventType"`
+	SessionID      string     `json:"sessionId"`
+	MachineId      string     `json:"machineId"`
+	DataItemId     string     `json:"dataItemId"`
+	NativeCode     string     `json:"nativeCode,omitempty"`
+	NativeSeverity string     `json:"nativeSeverity,omitempty"`
+	Qualifier      string     `json:"qualifier,omitempty"`
+	Level          string     `json:"level"`
+	PreviousLevel  string     `json:"previousLevel,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	ComponentId    string     `json:"componentId,omitempty"`
+	ComponentName  string     `json:"componentName,omitempty"`
+	Message        string     `json:"message,omitempty"`
+	StartTime      time.Time  `json:"startTime"`
+	EndTime        *time.Time `json:"endTime,omitempty"`
+	DurationMs     int64      `json:"durationMs,omitempty"`
+	// Reason указывает причину снятия аварии: NORMAL или UNAVAILABLE
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// Role - роль клиента API; каждая следующая роль включает права предыдущей
+type Role string
+
+const (
+	// RoleViewer - чтение подключений, данных станков, аварий и потоков событий
+	RoleViewer Role = "viewer"
+	// RoleOperator - управление опросом
+	RoleOperator Role = "operator"
+	// RoleAdmin - управление подключениями
+	RoleAdmin Role = "admin"
+)
+
+var roleLevels = map[Role]int{RoleViewer: 1, RoleOperator: 2, RoleAdmin: 3}
+
+// ParseRole проверяет название роли из конфигурации или JWT
+func ParseRole(value string) (Role, bool) {
+	role := Role(value)
+	_, ok := roleLevels[role]
+	return role, ok
+}
+
+// Allows сообщает, достаточно ли роли для операции, требующей роль required
+func (r Role) Allows(required Role) bool {
+	level, ok := roleLevels[r]
+	return ok && level >= roleLevels[required]
+}
+
+// Способы аутентификации клиента
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+	// AuthMethodNone - аутентификация выключена, клиент получает роль admin
+	AuthMethodNone = "none"
+)
+
+// Principal - аутентифицированный клиент API
+type Principal struct {
+	Subject    string
+	Role       Role
+	AuthMethod string
+}
+
+// AuditRecord - запись журнала аудита об изменяющей операции или попытке ее выполнить
+type AuditRecord struct {
+	Time       time.Time `json:"time"`
+	Subject    string    `json:"subject"`
+	Role       Role      `json:"role,omitempty"`
+	AuthMethod string    `json:"authMethod,omitempty"`
+	// Transport - http или grpc
+	Transport string `json:"transport"`
+	// Action - метод и путь HTTP или полное имя метода gRPC
+	Action     string `json:"action"`
+	RemoteAddr string `json:"remoteAddr"`
+	// Request - тело запроса (JSON), если оно есть
+	Request json.RawMessage `json:"request,omitempty"`
+	// Result - OK или код ошибки (ErrorCode)
+	Result string `json:"result"`
+	// Status - статус ответа HTTP
+	Status int `json:"status,omitempty"`
+}
+
+// RedactAuditRequest возвращает тело запроса (JSON) в компактной форме, в которой адреса с учетными данными,
+// параметрами запроса или фрагментом заменены безопасной формой RedactURL: адреса агентов могут содержать токены.
+// Для тела, которое не разбирается как JSON, возвращается nil.
+func RedactAuditRequest(body []byte) json.RawMessage {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil || decoder.More() {
+		return nil
+	}
+	raw, err := json.Marshal(redactURLs(document))
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+// RedactURL скрывает учетные данные и параметры запроса, которые могут содержать токены
+func RedactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	redacted.RawQuery = ""
+	redacted.Fragment = ""
+	return redacted.String()
+}
+
+func redactURLs(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = redactURLs(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactURLs(item)
+		}
+	case string:
+		u, err := url.Parse(v)
+		if err == nil && u.Scheme != "" && u.Host != "" && (u.User != nil || u.RawQuery != "" || u.Fragment != "") {
+			return RedactURL(u)
+		}
+	}
+	return value
+}
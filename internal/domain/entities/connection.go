@@ -1,12 +1,75 @@
 package entities
 
-import "time"
+import (
+	"regexp"
+	"strings"
+	"time"
+)
 
 // ConnectionRequest определяет структуру для нового запроса на подключение.
+// Устройство выбирается по селектору Match, а если он не задан - по модели Model.
 type ConnectionRequest struct {
-	EndpointURL  string `json:"EndpointURL" binding:"required"`
-	Model        string `json:"Model" binding:"required"`
-	Manufacturer string `json:"Manufacturer,omitempty"`
+	EndpointURL  string          `json:"EndpointURL" binding:"required"`
+	Model        string          `json:"Model,omitempty"`
+	Manufacturer string          `json:"Manufacturer,omitempty"`
+	Match        *DeviceSelector `json:"Match,omitempty"`
+}
+
+// Validate проверяет, что задан адрес агента и способ выбора устройства
+func (r ConnectionRequest) Validate() error {
+	if r.EndpointURL == "" {
+		return NewError(ErrorCodeInvalidArgument, "поле EndpointURL обязательно")
+	}
+	if r.Match == nil {
+		if r.Model == "" {
+			return NewError(ErrorCodeInvalidArgument, "требуется Model или селектор устройства Match")
+		}
+		return nil
+	}
+	return r.Match.Validate()
+}
+
+// DeviceSelector выбирает устройство агента по атрибутам из /probe. Заданные поля должны
+// совпасть все; строки, кроме Regex, сравниваются без учета регистра.
+type DeviceSelector struct {
+	UUID         string `json:"UUID,omitempty"`
+	Name         string `json:"Name,omitempty"`
+	SerialNumber string `json:"SerialNumber,omitempty"`
+	// Model - атрибут model тега Description
+	Model string `json:"Model,omitempty"`
+	// Regex - регулярное выражение для текста тега Description
+	Regex string `json:"Regex,omitempty"`
+}
+
+// IsEmpty сообщает, что в селекторе не задано ни одно условие
+func (s DeviceSelector) IsEmpty() bool {
+	return s == DeviceSelector{}
+}
+
+// Validate проверяет, что селектор содержит условие и корректное регулярное выражение
+func (s DeviceSelector) Validate() error {
+	if s.IsEmpty() {
+		return NewError(ErrorCodeInvalidArgument, "селектор Match должен содержать хотя бы одно из полей UUID, Name, SerialNumber, Model, Regex")
+	}
+	if s.Regex != "" {
+		if _, err := regexp.Compile(s.Regex); err != nil {
+			return NewError(ErrorCodeInvalidArgument, "некорректное регулярное выражение Match.Regex: %w", err)
+		}
+	}
+	return nil
+}
+
+// String перечисляет условия селектора для сообщений об ошибках
+func (s DeviceSelector) String() string {
+	var parts []string
+	for _, field := range []struct{ name, value string }{
+		{"UUID", s.UUID}, {"Name", s.Name}, {"SerialNumber", s.SerialNumber}, {"Model", s.Model}, {"Regex", s.Regex},
+	} {
+		if field.value != "" {
+			parts = append(parts, field.name+"="+field.value)
+		}
+	}
+	return strings.Join(parts, ", ")
 }
 
 // SessionRequest определяет структуру для запросов, использующих SessionID.
@@ -14,20 +77,107 @@ type SessionRequest struct {
 	SessionID string `json:"SessionID" binding:"required"`
 }
 
+// PollingRequest - необязательное тело запроса POST /api/v1/polling:start.
+type PollingRequest struct {
+	IntervalMs int `json:"IntervalMs"`
+}
+
 // ConnectionConfig содержит проверенную конфигурацию подключения.
 type ConnectionConfig struct {
-	EndpointURL  string `json:"EndpointURL"`
-	Model        string `json:"Model"`
-	Manufacturer string `json:"Manufacturer,omitempty"`
+	EndpointURL  string          `json:"EndpointURL"`
+	Model        string          `json:"Model"`
+	Manufacturer string          `json:"Manufacturer,omitempty"`
+	Match        *DeviceSelector `json:"Match,omitempty"`
 }
 
 // ConnectionInfo представляет активное подключение в пуле.
 type ConnectionInfo struct {
 	SessionID string           `json:"SessionID"`
-	MachineID string           `json:"-"` // Внутренний идентификатор станка из probe
+	MachineID string           `json:"MachineID"` // Идентификатор станка из probe
 	Config    ConnectionConfig `json:"Config"`
 	CreatedAt time.Time        `json:"CreatedAt"`
 	LastUsed  time.Time        `json:"LastUsed"`
 	UseCount  int64            `json:"UseCount"`
 	IsHealthy bool             `json:"IsHealthy"`
 }
+
+// ConnectionPatch содержит изменяемые поля подключения; незаданные (nil) поля остаются прежними.
+// Пустой селектор Match удаляет селектор, и устройство снова выбирается по модели.
+type ConnectionPatch struct {
+	EndpointURL  *string         `json:"EndpointURL,omitempty"`
+	Model        *string         `json:"Model,omitempty"`
+	Manufacturer *string         `json:"Manufacturer,omitempty"`
+	Match        *DeviceSelector `json:"Match,omitempty"`
+}
+
+// Поля сортировки списка подключений; префикс "-" задает сортировку по убыванию
+const (
+	ConnectionSortCreatedAt = "created_at"
+	ConnectionSortLastUsed  = "last_used"
+	ConnectionSortMachineID = "machine_id"
+	ConnectionSortSessionID = "session_id"
+	ConnectionSortUseCount  = "use_count"
+)
+
+// Ограничения размера страницы списка подключений
+const (
+	DefaultConnectionPageLimit = 50
+	MaxConnectionPageLimit     = 500
+)
+
+// ConnectionQuery задает фильтры, сортировку и страницу списка подключений.
+// Пустые строковые фильтры и Healthy == nil не ограничивают выборку.
+type ConnectionQuery struct {
+	MachineID    string
+	Manufacturer string
+	Model        string
+	Healthy      *bool
+	Sort         string
+	Limit        int
+	Offset       int
+}
+
+// ConnectionPage - страница списка подключений; Total - число подключений, подходящих под фильтры.
+type ConnectionPage struct {
+	Total  int
+	Limit  int
+	Offset int
+	Items  []*ConnectionInfo
+}
+
+// Ограничения пакетного создания подключений
+const (
+	MaxBulkConnections     = 1000
+	DefaultBulkConcurrency = 8
+	MaxBulkConcurrency     = 64
+)
+
+// BulkConnectionRequest - пакет подключений для создания. Формат совместим с результатом экспорта
+// и с разделом connections конфигурации.
+type BulkConnectionRequest struct {
+	Connections []ConnectionRequest `json:"connections"`
+	// Concurrency - число агентов, опрашиваемых одновременно; 0 - значение по умолчанию
+	Concurrency int `json:"concurrency,omitempty"`
+}
+
+// BulkConnectionResult - результат создания одного подключения пакета.
+// При ошибке Connection пуст, а Code и Message описывают причину.
+type BulkConnectionResult struct {
+	Index      int               `json:"Index"`
+	Request    ConnectionRequest `json:"Request"`
+	Connection *ConnectionInfo   `json:"Connection,omitempty"`
+	Code       ErrorCode         `json:"Code,omitempty"`
+	Message    string            `json:"Message,omitempty"`
+}
+
+// SetError отмечает элемент пакета как неудачный
+func (r *BulkConnectionResult) SetError(err error) {
+	r.Connection = nil
+	r.Code = ErrorCodeOf(err)
+	r.Message = err.Error()
+}
+
+// ConnectionExport - список подключений в формате раздела connections конфигурации
+type ConnectionExport struct {
+	Connections []ConnectionRequest `json:"connections"`
+}
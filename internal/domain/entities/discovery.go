@@ -0,0 +1,26 @@
+package entities
+
+// DiscoveredDevice описывает устройство из ответа /probe агента
+type DiscoveredDevice struct {
+	Name         string `json:"Name"`
+	UUID         string `json:"UUID,omitempty"`
+	ID           string `json:"ID"`
+	Manufacturer string `json:"Manufacturer,omitempty"`
+	Model        string `json:"Model,omitempty"`
+	SerialNumber string `json:"SerialNumber,omitempty"`
+	// Description - текст тега Description с нормализованными пробелами
+	Description string `json:"Description,omitempty"`
+	// Components - число компонентов устройства по типу элемента (Axes, Linear, Controller, Path, ...)
+	Components map[string]int `json:"Components"`
+	// DataItems - общее число элементов данных устройства и его компонентов
+	DataItems int `json:"DataItems"`
+	// SessionID - подключение, уже созданное для устройства на этом эндпоинте
+	SessionID string `json:"SessionID,omitempty"`
+}
+
+// DiscoverConnectRequest - запрос на создание подключений для устройств агента
+type DiscoverConnectRequest struct {
+	EndpointURL string `json:"EndpointURL" binding:"required"`
+	// Devices - имена, UUID или id устройств; пустой список означает все устройства агента
+	Devices []string `json:"Devices"`
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode - стабильный машиночитаемый код ошибки, который получают клиенты REST и gRPC API
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidArgument ErrorCode = "INVALID_ARGUMENT"
+	// ErrorCodeNotFound - сессия или данные станка не найдены
+	ErrorCodeNotFound ErrorCode = "NOT_FOUND"
+	// ErrorCodeAlreadyExists - подключение или опрос уже существуют
+	ErrorCodeAlreadyExists ErrorCode = "ALREADY_EXISTS"
+	// ErrorCodeAgentUnreachable - агент MTConnect не отвечает или отвечает ошибкой HTTP
+	ErrorCodeAgentUnreachable ErrorCode = "AGENT_UNREACHABLE"
+	// ErrorCodeProbeInvalid - ответ /probe не разбирается или не содержит устройств
+	ErrorCodeProbeInvalid ErrorCode = "PROBE_INVALID"
+	// ErrorCodeModelNotFound - на агенте нет устройства с указанной моделью и производителем
+	ErrorCodeModelNotFound ErrorCode = "MODEL_NOT_FOUND"
+	// ErrorCodeDeviceAmbiguous - под модель или селектор подходит несколько устройств агента
+	ErrorCodeDeviceAmbiguous ErrorCode = "DEVICE_AMBIGUOUS"
+	// ErrorCodeUnauthenticated - не передан или не прошел проверку API-ключ или JWT
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+	// ErrorCodePermissionDenied - роли клиента недостаточно для операции
+	ErrorCodePermissionDenied ErrorCode = "PERMISSION_DENIED"
+	// ErrorCodeUnavailable - сервис временно не может обработать запрос (например, превышен лимит подписчиков)
+	ErrorCodeUnavailable ErrorCode = "UNAVAILABLE"
+	ErrorCodeInternal    ErrorCode = "INTERNAL"
+)
+
+// DomainError - ошибка предметной области с кодом, по которому транспортный слой выбирает статус ответа
+type DomainError struct {
+	Code    ErrorCode
+	Message string
+	Err     error
+}
+
+// NewError создает ошибку с кодом; формат и аргументы как у fmt.Errorf, причина через %w сохраняется
+func NewError(code ErrorCode, format string, args ...interface{}) *DomainError {
+	err := fmt.Errorf(format, args...)
+	return &DomainError{Code: code, Message: err.Error(), Err: errors.Unwrap(err)}
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// DeviceAmbiguityError - под модель или селектор подошло несколько устройств агента; Candidates
+// перечисляет их, чтобы клиент мог уточнить выбор
+type DeviceAmbiguityError struct {
+	*DomainError
+	Candidates []DeviceCandidate
+}
+
+// DeviceCandidate - устройство агента, подходящее под условия выбора
+type DeviceCandidate struct {
+	Name         string `json:"Name"`
+	UUID         string `json:"UUID,omitempty"`
+	Manufacturer string `json:"Manufacturer,omitempty"`
+	Model        string `json:"Model,omitempty"`
+	SerialNumber string `json:"SerialNumber,omitempty"`
+}
+
+func (e *DeviceAmbiguityError) Unwrap() error {
+	return e.DomainError
+}
+
+// ErrorCodeOf возвращает код первой ошибки предметной области в цепочке; остальные ошибки считаются внутренними
+func ErrorCodeOf(err error) ErrorCode {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return ErrorCodeInternal
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// FlattenMachineData раскладывает MachineData в плоскую карту "путь.к.полю" -> значение.
+// Элементы массивов адресуются по идентификатору (id, dataItemId[/nativeCode]),
+// а при его отсутствии - по индексу, поэтому пути стабильны между снимками.
+func FlattenMachineData(data MachineData) map[string]interface{} {
+	return FlattenValue(data)
+}
+
+// FlattenValue раскладывает произвольное значение, сериализуемое в JSON, в плоскую карту по тем же правилам
+func FlattenValue(data interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fields
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fields
+	}
+	flattenValue("", doc, fields)
+	return fields
+}
+
+func flattenValue(prefix string, value interface{}, out map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+			return
+		}
+		for key, item := range v {
+			flattenValue(joinPath(prefix, key), item, out)
+		}
+	case []interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+			return
+		}
+		for i, item := range v {
+			flattenValue(joinPath(prefix, arrayElementKey(item, i)), item, out)
+		}
+	default:
+		out[prefix] = v
+	}
+}
+
+// arrayElementKey выбирает стабильный ключ для элемента массива
+func arrayElementKey(item interface{}, index int) string {
+	if obj, ok := item.(map[string]interface{}); ok {
+		if id, ok := obj["id"].(string); ok && id != "" {
+			return id
+		}
+		if id, ok := obj["dataItemId"].(string); ok && id != "" {
+			if code, ok := obj["nativeCode"].(string); ok && code != "" {
+				return id + "/" + code
+			}
+			return id
+		}
+		if code, ok := obj["nativeCode"].(string); ok && code != "" {
+			return code
+		}
+	}
+	return strconv.Itoa(index)
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,77 @@
+package entities
+
+import "time"
+
+// Состояния проверок работоспособности. degraded не снимает готовность сервиса,
+// а только сообщает о частичной неисправности.
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusFail     = "fail"
+)
+
+// Компоненты проверки готовности
+const (
+	HealthComponentSinks    = "sinks"
+	HealthComponentSessions = "sessions"
+	HealthComponentPolling  = "polling"
+)
+
+// HealthReport - результат проверки готовности с разбивкой по компонентам
+type HealthReport struct {
+	Status     string            `json:"Status"`
+	Timestamp  time.Time         `json:"Timestamp"`
+	Components []ComponentHealth `json:"Components"`
+}
+
+// ComponentHealth - состояние одного компонента проверки готовности
+type ComponentHealth struct {
+	Name    string      `json:"Name"`
+	Status  string      `json:"Status"`
+	Message string      `json:"Message,omitempty"`
+	Details interface{} `json:"Details,omitempty"`
+}
+
+// SinkCheck - результат проверки связи синка с внешней системой
+type SinkCheck struct {
+	Name   string `json:"Name"`
+	Type   string `json:"Type"`
+	Status string `json:"Status"`
+	// Buffered - сообщения синка сохраняются в дисковую очередь, пока внешняя система недоступна
+	Buffered bool   `json:"Buffered"`
+	Error    string `json:"Error,omitempty"`
+}
+
+// PollState - состояние цикла опроса сессии
+type PollState struct {
+	SessionID string        `json:"SessionID"`
+	MachineID string        `json:"MachineID"`
+	Interval  time.Duration `json:"-"`
+	StartedAt time.Time     `json:"StartedAt"`
+	// LastData - время последнего снимка MachineData, полученного опросом
+	LastData *time.Time `json:"LastData,omitempty"`
+	// Reachable - результат последнего обращения к агенту; nil, пока опросов не было
+	Reachable *bool `json:"Reachable,omitempty"`
+}
+
+// SessionHealth - сессия, отмеченная проверкой готовности как неисправная или зависшая
+type SessionHealth struct {
+	SessionID string     `json:"SessionID"`
+	MachineID string     `json:"MachineID"`
+	Reason    string     `json:"Reason"`
+	LastData  *time.Time `json:"LastData,omitempty"`
+}
+
+// SessionsHealth - подробности компонента sessions
+type SessionsHealth struct {
+	Total     int             `json:"Total"`
+	Healthy   int             `json:"Healthy"`
+	Unhealthy []SessionHealth `json:"Unhealthy,omitempty"`
+}
+
+// PollingHealth - подробности компонента polling
+type PollingHealth struct {
+	Active        int             `json:"Active"`
+	Stale         int             `json:"Stale"`
+	StaleSessions []SessionHealth `json:"StaleSessions,omitempty"`
+}
@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+// Типы событий жизненного цикла
+const (
+	LifecycleConnectionCreated = "connection_created"
+	LifecycleConnectionDeleted = "connection_deleted"
+	// LifecycleConnectionUpdated - изменены эндпоинт, модель или производитель подключения
+	LifecycleConnectionUpdated = "connection_updated"
+	LifecyclePollingStarted    = "polling_started"
+	LifecyclePollingStopped    = "polling_stopped"
+	// LifecycleMachineUnreachable и LifecycleMachineRecovered отмечают потерю и восстановление связи с агентом
+	LifecycleMachineUnreachable = "machine_unreachable"
+	LifecycleMachineRecovered   = "machine_recovered"
+	// LifecycleAgentRestarted - агент MTConnect перезапущен (изменился instanceId), последовательности начаты заново
+	LifecycleAgentRestarted = "agent_restarted"
+)
+
+// LifecycleEvent - событие жизненного цикла подключения или сервиса,
+// отправляемое во внешние системы наряду с данными станков
+type LifecycleEvent struct {
+	EventType    string            `json:"eventType"`
+	SessionID    string            `json:"sessionId,omitempty"`
+	MachineId    string            `json:"machineId,omitempty"`
+	Manufacturer string            `json:"manufacturer,omitempty"`
+	EndpointURL  string            `json:"endpointUrl,omitempty"`
+	Timestamp    time.Time         `json:"timestamp"`
+	Message      string            `json:"message,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+// LogLevels описывает уровни журнала сервиса
+type LogLevels struct {
+	// Level - уровень по умолчанию для компонентов без собственного уровня
+	Level      string              `json:"Level"`
+	Components []ComponentLogLevel `json:"Components"`
+}
+
+// ComponentLogLevel - действующий уровень журнала компонента
+type ComponentLogLevel struct {
+	Name  string `json:"Name"`
+	Level string `json:"Level"`
+	// Override - уровень задан для компонента явно и не следует уровню по умолчанию
+	Override bool `json:"Override"`
+}
+
+// LogLevelsUpdate изменяет уровни журнала; незаданные поля остаются прежними.
+// Пустой уровень компонента удаляет его собственный уровень, и компонент снова следует уровню по умолчанию.
+type LogLevelsUpdate struct {
+	Level      *string           `json:"Level,omitempty"`
+	Components map[string]string `json:"Components,omitempty"`
+}
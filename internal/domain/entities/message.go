@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+// Типы сообщений, публикуемых во внешние системы
+const (
+	MessageTypeSnapshot  = "snapshot"
+	MessageTypeDelta     = "delta"
+	MessageTypeAlarm     = "alarm"
+	MessageTypeLifecycle = "lifecycle"
+	// MessageTypeObservation - исходное наблюдение MTConnect, одно сообщение на изменение DataItem
+	MessageTypeObservation = "observation"
+)
+
+// Message - сообщение для публикации в синки.
+// Payload содержит исходную структуру (MachineData, MachineDataDelta, AlarmEvent, LifecycleEvent, Observation),
+// а Value и ContentType заполняются кодировщиком конкретного синка.
+type Message struct {
+	// ID - уникальный идентификатор сообщения, общий для всех синков и повторных отправок
+	ID           string    `json:"id"`
+	Type         string    `json:"type"`
+	Key          string    `json:"key"`
+	SessionID    string    `json:"sessionId,omitempty"`
+	MachineID    string    `json:"machineId"`
+	Manufacturer string    `json:"manufacturer,omitempty"`
+	EndpointURL  string    `json:"endpointUrl,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	// AgentInstanceID, FirstSequence и LastSequence описывают источник данных в потоке агента
+	AgentInstanceID string            `json:"agentInstanceId,omitempty"`
+	FirstSequence   uint64            `json:"firstSequence,omitempty"`
+	LastSequence    uint64            `json:"lastSequence,omitempty"`
+	Payload         interface{}       `json:"-"`
+	Value           []byte            `json:"value,omitempty"`
+	ContentType     string            `json:"contentType,omitempty"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	// Targets - получатели синка, еще не принявшие сообщение (идентификаторы адресов webhook).
+	// Пустой список означает всех получателей; заполняется при частичной доставке,
+	// чтобы повторная отправка из outbox не дублировала сообщение уже принявшим его адресам.
+	Targets []string `json:"targets,omitempty"`
+}
+
+// Clone возвращает копию сообщения, которую синк может изменять независимо от остальных
+func (m *Message) Clone() *Message {
+	clone := *m
+	if m.Headers != nil {
+		clone.Headers = make(map[string]string, len(m.Headers))
+		for k, v := range m.Headers {
+			clone.Headers[k] = v
+		}
+	}
+	if m.Targets != nil {
+		clone.Targets = append([]string(nil), m.Targets...)
+	}
+	return &clone
+}
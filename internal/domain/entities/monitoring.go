@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+// OutboxStats описывает состояние локальной очереди неотправленных сообщений
+type OutboxStats struct {
+	Name         string  `json:"Name"`
+	Depth        int     `json:"Depth"`
+	Bytes        int64   `json:"Bytes"`
+	MaxBytes     int64   `json:"MaxBytes"`
+	OldestAgeSec float64 `json:"OldestAgeSec"`
+	Dropped      uint64  `json:"Dropped"`
+	LastError    string  `json:"LastError,omitempty"`
+}
+
+// SinkStats описывает состояние одного синка публикации
+type SinkStats struct {
+	Name          string      `json:"Name"`
+	Type          string      `json:"Type"`
+	Queued        int         `json:"Queued"`
+	QueueCapacity int         `json:"QueueCapacity"`
+	Delivered     uint64      `json:"Delivered"`
+	Failed        uint64      `json:"Failed"`
+	Dropped       uint64      `json:"Dropped"`
+	LastError     string      `json:"LastError,omitempty"`
+	LastDelivery  *time.Time  `json:"LastDelivery,omitempty"`
+	Details       interface{} `json:"Details,omitempty"`
+}
+
+// WebhookTargetStats описывает состояние доставки на один адрес синка webhook
+type WebhookTargetStats struct {
+	URL                 string     `json:"URL"`
+	Delivered           uint64     `json:"Delivered"`
+	Failed              uint64     `json:"Failed"`
+	Retries             uint64     `json:"Retries"`
+	Skipped             uint64     `json:"Skipped"`
+	ConsecutiveFailures int        `json:"ConsecutiveFailures"`
+	PausedUntil         *time.Time `json:"PausedUntil,omitempty"`
+	LastStatusCode      int        `json:"LastStatusCode,omitempty"`
+	LastLatencyMs       int64      `json:"LastLatencyMs,omitempty"`
+	LastError           string     `json:"LastError,omitempty"`
+	LastDelivery        *time.Time `json:"LastDelivery,omitempty"`
+}
+
+// FileSinkStats описывает состояние синка локального архива
+type FileSinkStats struct {
+	Dir          string     `json:"Dir"`
+	Format       string     `json:"Format"`
+	OpenFiles    int        `json:"OpenFiles"`
+	FilesCreated uint64     `json:"FilesCreated"`
+	FilesDeleted uint64     `json:"FilesDeleted"`
+	BytesWritten uint64     `json:"BytesWritten"`
+	LastRotation *time.Time `json:"LastRotation,omitempty"`
+	LastError    string     `json:"LastError,omitempty"`
+}
@@ -1,6 +1,9 @@
 package entities
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // AxisInfo содержит актуальную информацию о состоянии одной оси станка
 type AxisInfo struct {
@@ -57,6 +60,7 @@ type MachineData struct {
 	FeedOverride        map[string]string        `json:"FeedOverride"`
 	Alarms              []map[string]interface{} `json:"Alarms"`
 	HasAlarms           interface{}              `json:"hasAlarms"`
+	Conditions          []ConditionState         `json:"Conditions"`
 	PartsCount          map[string]string        `json:"PartsCount"`
 	AccumulatedTime     map[string]string        `json:"AccumulatedTime"`
 	CurrentProgram      *CurrentProgramInfo      `json:"CurrentProgram,omitempty"`
@@ -65,6 +69,37 @@ type MachineData struct {
 	JogOverride         interface{}              `json:"JogOverride"`
 }
 
+// Уровни состояния Condition DataItem'а согласно стандарту MTConnect
+const (
+	ConditionNormal      = "NORMAL"
+	ConditionWarning     = "WARNING"
+	ConditionFault       = "FAULT"
+	ConditionUnavailable = "UNAVAILABLE"
+)
+
+// ConditionActivation - одна активная активация условия, идентифицируемая nativeCode.
+// Один Condition DataItem может одновременно содержать несколько активаций.
+type ConditionActivation struct {
+	NativeCode     string `json:"nativeCode,omitempty"`
+	NativeSeverity string `json:"nativeSeverity,omitempty"`
+	Qualifier      string `json:"qualifier,omitempty"`
+	Level          string `json:"level"`
+	Message        string `json:"message,omitempty"`
+	Timestamp      string `json:"timestamp,omitempty"`
+}
+
+// ConditionState содержит состояние одного Condition DataItem'а.
+// State принимает значения NORMAL, WARNING, FAULT или UNAVAILABLE (источник недоступен).
+type ConditionState struct {
+	DataItemId    string                `json:"dataItemId"`
+	Type          string                `json:"type,omitempty"`
+	ComponentId   string                `json:"componentId,omitempty"`
+	ComponentName string                `json:"componentName,omitempty"`
+	State         string                `json:"state"`
+	Timestamp     string                `json:"timestamp,omitempty"`
+	Activations   []ConditionActivation `json:"activations"`
+}
+
 // DataItemMetadata хранит метаданные из /probe для каждого DataItem
 type DataItemMetadata struct {
 	ID            string
@@ -75,6 +110,8 @@ type DataItemMetadata struct {
 	Category      string
 	Type          string
 	SubType       string
+	// ComponentPath - путь компонента от устройства, например Mazak/Axes[base]/Linear[X]
+	ComponentPath string
 }
 
 // AxisDataItemLink - структура для связи DataItem'а с конкретной осью
@@ -144,9 +181,21 @@ type DataItem struct {
 
 type MTConnectStreams struct {
 	XMLName xml.Name       `xml:"MTConnectStreams"`
+	Header  StreamsHeader  `xml:"Header"`
 	Streams []DeviceStream `xml:"Streams>DeviceStream"`
 }
 
+// StreamsHeader - заголовок ответа агента. InstanceId меняется при перезапуске агента,
+// после чего нумерация последовательностей начинается заново.
+type StreamsHeader struct {
+	InstanceId    string `xml:"instanceId,attr"`
+	Sender        string `xml:"sender,attr"`
+	CreationTime  string `xml:"creationTime,attr"`
+	FirstSequence uint64 `xml:"firstSequence,attr"`
+	LastSequence  uint64 `xml:"lastSequence,attr"`
+	NextSequence  uint64 `xml:"nextSequence,attr"`
+}
+
 type DeviceStream struct {
 	Name             string            `xml:"name,attr"`
 	UUID             string            `xml:"uuid,attr"`
@@ -175,6 +224,7 @@ type Conditions struct {
 type SampleValue struct {
 	XMLName    xml.Name
 	DataItemId string `xml:"dataItemId,attr"`
+	Sequence   uint64 `xml:"sequence,attr"`
 	Timestamp  string `xml:"timestamp,attr"`
 	Name       string `xml:"name,attr"`
 	SubType    string `xml:"subType,attr"`
@@ -184,17 +234,48 @@ type SampleValue struct {
 type EventValue struct {
 	XMLName    xml.Name
 	DataItemId string `xml:"dataItemId,attr"`
+	Sequence   uint64 `xml:"sequence,attr"`
 	Timestamp  string `xml:"timestamp,attr"`
 	Name       string `xml:"name,attr"`
 	Value      string `xml:",chardata"`
 }
 
 type ConditionValue struct {
-	XMLName    xml.Name
-	DataItemId string `xml:"dataItemId,attr"`
-	Timestamp  string `xml:"timestamp,attr"`
-	Name       string `xml:"name,attr"`
-	Type       string `xml:"type,attr"`
-	NativeCode string `xml:"nativeCode,attr"`
-	Value      string `xml:",chardata"`
+	XMLName        xml.Name
+	DataItemId     string `xml:"dataItemId,attr"`
+	Sequence       uint64 `xml:"sequence,attr"`
+	Timestamp      string `xml:"timestamp,attr"`
+	Name           string `xml:"name,attr"`
+	Type           string `xml:"type,attr"`
+	NativeCode     string `xml:"nativeCode,attr"`
+	NativeSeverity string `xml:"nativeSeverity,attr"`
+	Qualifier      string `xml:"qualifier,attr"`
+	Value          string `xml:",chardata"`
+}
+
+// StreamPosition - положение данных в потоке агента: экземпляр агента и диапазон
+// последовательностей наблюдений, вошедших в сообщение. Позволяет потребителям отбрасывать дубликаты.
+type StreamPosition struct {
+	AgentInstanceID string
+	FirstSequence   uint64
+	LastSequence    uint64
+}
+
+// MachineDataDelta - изменения полей MachineData относительно последнего опубликованного состояния
+type MachineDataDelta struct {
+	MachineId string                 `json:"MachineId"`
+	Id        string                 `json:"Id"`
+	Timestamp string                 `json:"Timestamp"`
+	Changed   map[string]interface{} `json:"Changed"`
+	Removed   []string               `json:"Removed,omitempty"`
+}
+
+// PublishedState хранит последнее опубликованное состояние станка в плоском виде.
+// Используется для публикации только изменений и для расчета зоны нечувствительности.
+// Состояние обновляется при постановке сообщения в очереди синков, а не при его доставке:
+// если синк потеряет сообщение, потребитель восстановит состояние по следующему ключевому снимку.
+type PublishedState struct {
+	Fields      map[string]interface{}
+	KeyframeAt  time.Time
+	PublishedAt time.Time
 }
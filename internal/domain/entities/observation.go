@@ -0,0 +1,26 @@
+package entities
+
+// Observation - исходное наблюдение MTConnect (Sample, Event или Condition) в том виде,
+// в котором его передал агент, с метаданными DataItem из /probe
+type Observation struct {
+	MachineId  string `json:"machineId"`
+	DeviceUUID string `json:"deviceUuid,omitempty"`
+	// Sequence - номер наблюдения в буфере агента
+	Sequence   uint64 `json:"sequence"`
+	Timestamp  string `json:"timestamp"`
+	DataItemId string `json:"dataItemId"`
+	Name       string `json:"name,omitempty"`
+	// Category - SAMPLE, EVENT или CONDITION
+	Category string `json:"category"`
+	Type     string `json:"type"`
+	SubType  string `json:"subType,omitempty"`
+	// ComponentPath - путь компонента от устройства, например Mazak/Axes[base]/Linear[X]
+	ComponentPath string `json:"componentPath,omitempty"`
+	// Value - значение наблюдения; для условий - уровень (Normal, Warning, Fault, Unavailable)
+	Value          string `json:"value"`
+	NativeCode     string `json:"nativeCode,omitempty"`
+	NativeSeverity string `json:"nativeSeverity,omitempty"`
+	Qualifier      string `json:"qualifier,omitempty"`
+	// Message - текст условия
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// Типы событий потоков реального времени
+const (
+	StreamEventMachineData = "machine_data"
+	StreamEventAlarm       = "alarm"
+	// StreamEventLifecycle - события подключения, в том числе потеря и восстановление связи со станком
+	StreamEventLifecycle = "lifecycle"
+)
+
+// StreamEvent - событие, рассылаемое подписчикам SSE и WebSocket.
+// Data содержит MachineData, AlarmEvent или LifecycleEvent.
+type StreamEvent struct {
+	ID        uint64      `json:"id"`
+	Event     string      `json:"event"`
+	SessionID string      `json:"sessionId"`
+	MachineID string      `json:"machineId"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// StreamFilter - условия подписки. Пустой список означает "все".
+type StreamFilter struct {
+	SessionIDs []string
+	Events     []string
+	// Fields - поля MachineData верхнего уровня (MachineId, Id и Timestamp сохраняются всегда)
+	Fields []string
+}
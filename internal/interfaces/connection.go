@@ -5,8 +5,16 @@ import "MTConnect/internal/domain/entities"
 // ConnectionService определяет контракт для управления пулом подключений.
 type ConnectionService interface {
 	CreateConnection(req entities.ConnectionRequest) (*entities.ConnectionInfo, error)
+	// CreateConnections создает подключения пакетом, запрашивая /probe каждого агента один раз
+	CreateConnections(reqs []entities.ConnectionRequest, concurrency int) []entities.BulkConnectionResult
+	// DiscoverDevices возвращает все устройства из /probe агента
+	DiscoverDevices(endpointURL string) ([]entities.DiscoveredDevice, error)
+	// ConnectDevices создает подключения для выбранных устройств агента; пустой список - все устройства
+	ConnectDevices(endpointURL string, selectors []string) ([]entities.BulkConnectionResult, error)
 	GetConnection(sessionID string) (*entities.ConnectionInfo, bool)
 	GetAllConnections() []*entities.ConnectionInfo
+	// UpdateConnection заново проверяет измененную конфигурацию по /probe и заменяет подключение в пуле
+	UpdateConnection(sessionID string, patch entities.ConnectionPatch) (*entities.ConnectionInfo, error)
 	DeleteConnection(sessionID string) error
 	CheckConnection(sessionID string) (*entities.ConnectionInfo, error)
 }
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+)
+
+// Metrics регистрирует показатели работы сервиса и отдает их в формате Prometheus
+type Metrics interface {
+	// ObservePoll учитывает длительность цикла опроса сессии: запрос /current, разбор и публикация
+	ObservePoll(sessionID, machineID string, duration time.Duration)
+	// FetchError учитывает ошибку получения или разбора ответа агента; kind - класс ошибки
+	FetchError(sessionID, kind string)
+	// ObserveParse учитывает длительность этапа разбора: "unmarshal" или "map"
+	ObserveParse(stage string, duration time.Duration)
+	// DataItems учитывает элементы данных ответа: сопоставленные и отброшенные из-за отсутствия метаданных
+	DataItems(machineID string, mapped, dropped int)
+	// ObserveProduce учитывает длительность и результат отправки сообщения синком
+	ObserveProduce(sink, sinkType string, duration time.Duration, err error)
+	// ObserveHTTPRequest учитывает запрос REST API; route - шаблон маршрута
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+	// ForgetSession удаляет показатели сессии после остановки ее опроса
+	ForgetSession(sessionID string)
+	// Handler отдает показатели по запросу Prometheus
+	Handler() http.Handler
+}
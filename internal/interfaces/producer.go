@@ -1,11 +1,34 @@
 package interfaces
 
 import (
+	"MTConnect/internal/domain/entities"
 	"context"
 )
 
-// DataProducer определяет контракт для отправки данных во внешние системы (например, Kafka)
+// DataProducer определяет контракт для отправки данных во внешние системы (Kafka, MQTT, HTTP, файлы)
 type DataProducer interface {
-	Produce(ctx context.Context, key, value []byte) error
+	Produce(ctx context.Context, msg *entities.Message) error
 	Close() error
 }
+
+// OutboxMonitor предоставляет состояние дисковых очередей неотправленных сообщений
+type OutboxMonitor interface {
+	OutboxStats() []entities.OutboxStats
+}
+
+// SinkMonitor предоставляет состояние синков публикации
+type SinkMonitor interface {
+	SinkStats() []entities.SinkStats
+	// CheckSinks проверяет связь с внешними системами синков, поддерживающих проверку
+	CheckSinks(ctx context.Context) []entities.SinkCheck
+}
+
+// SinkDetailsProvider реализуется синками, которые публикуют собственную статистику доставки
+type SinkDetailsProvider interface {
+	SinkDetails() interface{}
+}
+
+// SinkHealthChecker реализуется синками, которые могут проверить связь с внешней системой
+type SinkHealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
@@ -11,4 +11,6 @@ type Repository interface {
 type DataStoreRepository interface {
 	Set(machineId string, data entities.MachineData)
 	Get(machineId string) (entities.MachineData, bool)
+	SetPublished(machineId string, state entities.PublishedState)
+	GetPublished(machineId string) (entities.PublishedState, bool)
 }
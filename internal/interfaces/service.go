@@ -15,4 +15,52 @@ type PollingService interface {
 	LoadMetadataForEndpoint(endpointURL string) error
 	// Новый метод для запуска опроса для нового подключения, если опрос уже активен
 	StartPollingForNewConnectionIfNeeded(conn *entities.ConnectionInfo) error
+	// PublishLifecycleEvent отправляет в синки событие жизненного цикла подключения
+	PublishLifecycleEvent(conn *entities.ConnectionInfo, eventType, message string, attributes map[string]string)
+	// ActivePolls возвращает число сессий с запущенным опросом
+	ActivePolls() int
+	// PollStates возвращает состояние запущенных циклов опроса
+	PollStates() []entities.PollState
+}
+
+// StreamHub рассылает события реального времени подписчикам SSE и WebSocket
+type StreamHub interface {
+	Publish(event entities.StreamEvent)
+	Subscribe(filter entities.StreamFilter, lastEventID uint64) (StreamSubscription, error)
+}
+
+// StreamSubscription - подписка на события StreamHub
+type StreamSubscription interface {
+	// Events закрывается, когда подписка завершена хабом (например, из-за переполнения очереди)
+	Events() <-chan entities.StreamEvent
+	// Err возвращает причину завершения подписки хабом
+	Err() error
+	Close()
+}
+
+// AlarmService определяет контракт для реестра активных аварий и формирования событий их жизненного цикла
+type AlarmService interface {
+	ProcessMachineData(sessionID string, data entities.MachineData) []entities.AlarmEvent
+	GetActiveAlarms(sessionID string) []entities.ActiveAlarm
+	ClearSession(sessionID string)
+}
+
+// Authenticator проверяет учетные данные клиентов REST и gRPC API
+type Authenticator interface {
+	// Enabled сообщает, включена ли аутентификация
+	Enabled() bool
+	// Authenticate проверяет API-ключ или JWT (передается один из них); при выключенной аутентификации возвращает роль admin
+	Authenticate(apiKey, bearerToken string) (entities.Principal, error)
+}
+
+// AuditLog записывает изменяющие операции клиентов API
+type AuditLog interface {
+	Record(record entities.AuditRecord)
+	Close() error
+}
+
+// LogLevelController изменяет уровни журнала сервиса во время работы
+type LogLevelController interface {
+	LogLevels() entities.LogLevels
+	SetLogLevels(update entities.LogLevelsUpdate) (entities.LogLevels, error)
 }
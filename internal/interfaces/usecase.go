@@ -2,20 +2,75 @@ package interfaces
 
 import (
 	"MTConnect/internal/domain/entities"
+	"context"
 	"time"
 )
 
 // Usecases - это агрегирующий интерфейс для всех use cases
 type Usecases interface {
 	ConnectionUsecase
+	DiscoveryUsecase
+	AlarmUsecase
+	DataUsecase
+	MonitoringUsecase
+	HealthUsecase
+	LoggingUsecase
+	StreamUsecase
 }
 
 // ConnectionUsecase определяет контракт для логики управления подключениями
 type ConnectionUsecase interface {
 	CreateConnection(req entities.ConnectionRequest) (*entities.ConnectionInfo, error)
+	// BulkCreateConnections создает пакет подключений и возвращает результат по каждому элементу
+	BulkCreateConnections(req entities.BulkConnectionRequest) ([]entities.BulkConnectionResult, error)
+	// ExportConnections возвращает конфигурации подключений, отобранных по фильтрам query, без постраничного вывода
+	ExportConnections(query entities.ConnectionQuery) (entities.ConnectionExport, error)
 	GetAllConnections() []*entities.ConnectionInfo
+	// ListConnections возвращает страницу подключений, отобранных и отсортированных по query
+	ListConnections(query entities.ConnectionQuery) (entities.ConnectionPage, error)
+	GetConnection(sessionID string) (*entities.ConnectionInfo, error)
+	UpdateConnection(sessionID string, patch entities.ConnectionPatch) (*entities.ConnectionInfo, error)
 	DeleteConnection(sessionID string) error
 	CheckConnection(sessionID string) (*entities.ConnectionInfo, error)
 	StartPolling(interval time.Duration) error
 	StopPolling() error
 }
+
+// DiscoveryUsecase определяет контракт для поиска устройств агента и их подключения
+type DiscoveryUsecase interface {
+	DiscoverDevices(endpointURL string) ([]entities.DiscoveredDevice, error)
+	ConnectDiscoveredDevices(req entities.DiscoverConnectRequest) ([]entities.BulkConnectionResult, error)
+}
+
+// AlarmUsecase определяет контракт для получения информации об активных авариях
+type AlarmUsecase interface {
+	GetActiveAlarms(sessionID string) ([]entities.ActiveAlarm, error)
+}
+
+// DataUsecase определяет контракт для получения актуальных данных станков
+type DataUsecase interface {
+	GetCurrentData(sessionID string) (entities.MachineData, error)
+}
+
+// MonitoringUsecase определяет контракт для получения служебных показателей сервиса
+type MonitoringUsecase interface {
+	GetOutboxStats() []entities.OutboxStats
+	GetSinkStats() []entities.SinkStats
+}
+
+// HealthUsecase определяет контракт проверки готовности сервиса
+type HealthUsecase interface {
+	GetReadiness(ctx context.Context) entities.HealthReport
+}
+
+// LoggingUsecase определяет контракт просмотра и изменения уровней журнала
+type LoggingUsecase interface {
+	GetLogLevels() entities.LogLevels
+	SetLogLevels(update entities.LogLevelsUpdate) (entities.LogLevels, error)
+}
+
+// StreamUsecase определяет контракт подписки на события реального времени
+type StreamUsecase interface {
+	// Subscribe возвращает подписку; события с идентификатором больше lastEventID, сохраненные в истории, отправляются первыми
+	Subscribe(filter entities.StreamFilter, lastEventID uint64) (StreamSubscription, error)
+}
@@ -0,0 +1,271 @@
+// Package logging содержит журнал сервиса на log/slog: JSON-записи с единым набором атрибутов
+// и уровнями отдельных компонентов, которые можно менять во время работы.
+package logging
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Компоненты журнала
+const (
+	ComponentApp         = "app"
+	ComponentFx          = "fx"
+	ComponentPoller      = "poller"
+	ComponentConnections = "connections"
+	ComponentAuth        = "auth"
+	ComponentAudit       = "audit"
+	ComponentSinks       = "sinks"
+	ComponentKafka       = "kafka"
+	ComponentMQTT        = "mqtt"
+	ComponentWebhook     = "webhook"
+	ComponentFile        = "file"
+	ComponentOutbox      = "outbox"
+	ComponentHTTP        = "http"
+	ComponentGRPC        = "grpc"
+	ComponentTracing     = "tracing"
+)
+
+// Имена атрибутов, общие для всех компонентов
+const (
+	KeyComponent  = "component"
+	KeySessionID  = "sessionId"
+	KeyMachineID  = "machineId"
+	KeyEndpoint   = "endpoint"
+	KeyErrorClass = "errorClass"
+	KeyError      = "error"
+	KeyTraceID    = "traceId"
+	KeySpanID     = "spanId"
+)
+
+// component - уровень журнала компонента
+type component struct {
+	level    slog.LevelVar
+	override bool
+}
+
+var (
+	mu           sync.Mutex
+	defaultLevel slog.Level
+	components   = make(map[string]*component)
+	// unnamed - уровень записей стандартного пакета log и библиотек, всегда следует уровню по умолчанию
+	unnamed = &component{}
+
+	root atomic.Pointer[slog.Handler]
+)
+
+func init() {
+	var handler slog.Handler = slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})
+	root.Store(&handler)
+}
+
+// Logger возвращает журнал компонента. Журналы можно создавать при инициализации пакета:
+// формат и уровни, заданные позже в Configure, применяются к уже созданным журналам.
+func Logger(name string) *slog.Logger {
+	mu.Lock()
+	c, ok := components[name]
+	if !ok {
+		c = &component{}
+		c.level.Set(defaultLevel)
+		components[name] = c
+	}
+	mu.Unlock()
+	return slog.New(&componentHandler{component: c}).With(KeyComponent, name)
+}
+
+// Configure задает формат и уровни журнала и направляет в него стандартный пакет log
+func Configure(cfg config.LoggingConfig) error {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+	options := &slog.HandlerOptions{Level: slog.LevelDebug}
+	var handler slog.Handler
+	switch cfg.Format {
+	case config.LogFormatJSON:
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	case config.LogFormatText:
+		handler = slog.NewTextHandler(os.Stderr, options)
+	default:
+		return fmt.Errorf("неизвестный формат журнала '%s'", cfg.Format)
+	}
+
+	update := entities.LogLevelsUpdate{Level: &cfg.Level, Components: cfg.Components}
+	if _, err := SetLevels(update); err != nil {
+		return err
+	}
+	root.Store(&handler)
+	slog.SetDefault(slog.New(&componentHandler{component: unnamed}))
+	Logger(ComponentApp).Info("журнал настроен", "defaultLevel", LevelName(level), "format", cfg.Format)
+	return nil
+}
+
+// ParseLevel разбирает имя уровня журнала
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, entities.NewError(entities.ErrorCodeInvalidArgument, "неизвестный уровень журнала '%s', допустимы debug, info, warn, error", name)
+}
+
+// LevelName возвращает имя уровня журнала в том виде, в каком он задается в конфигурации
+func LevelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
+
+// Levels возвращает уровень по умолчанию и действующие уровни компонентов
+func Levels() entities.LogLevels {
+	mu.Lock()
+	defer mu.Unlock()
+	levels := entities.LogLevels{Level: LevelName(defaultLevel), Components: make([]entities.ComponentLogLevel, 0, len(components))}
+	for name, c := range components {
+		levels.Components = append(levels.Components, entities.ComponentLogLevel{Name: name, Level: LevelName(c.level.Level()), Override: c.override})
+	}
+	sort.Slice(levels.Components, func(i, j int) bool { return levels.Components[i].Name < levels.Components[j].Name })
+	return levels
+}
+
+// SetLevels изменяет уровни журнала. Изменение применяется целиком или не применяется вовсе:
+// при неизвестном уровне или компоненте возвращается ошибка INVALID_ARGUMENT.
+func SetLevels(update entities.LogLevelsUpdate) (entities.LogLevels, error) {
+	mu.Lock()
+	newDefault := defaultLevel
+	if update.Level != nil {
+		level, err := ParseLevel(*update.Level)
+		if err != nil {
+			mu.Unlock()
+			return entities.LogLevels{}, err
+		}
+		newDefault = level
+	}
+	overrides := make(map[*component]*slog.Level, len(update.Components))
+	for name, value := range update.Components {
+		c, ok := components[name]
+		if !ok {
+			mu.Unlock()
+			return entities.LogLevels{}, entities.NewError(entities.ErrorCodeInvalidArgument, "неизвестный компонент журнала '%s', допустимы: %s", name, strings.Join(componentNamesUnsafe(), ", "))
+		}
+		if value == "" {
+			overrides[c] = nil
+			continue
+		}
+		level, err := ParseLevel(value)
+		if err != nil {
+			mu.Unlock()
+			return entities.LogLevels{}, err
+		}
+		overrides[c] = &level
+	}
+
+	defaultLevel = newDefault
+	unnamed.level.Set(newDefault)
+	for c, level := range overrides {
+		c.override = level != nil
+		if level != nil {
+			c.level.Set(*level)
+		}
+	}
+	for _, c := range components {
+		if !c.override {
+			c.level.Set(newDefault)
+		}
+	}
+	mu.Unlock()
+	return Levels(), nil
+}
+
+func componentNamesUnsafe() []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LevelController предоставляет управление уровнями журнала сценариям использования
+type LevelController struct{}
+
+func NewLevelController() interfaces.LogLevelController {
+	return LevelController{}
+}
+
+func (LevelController) LogLevels() entities.LogLevels {
+	return Levels()
+}
+
+func (LevelController) SetLogLevels(update entities.LogLevelsUpdate) (entities.LogLevels, error) {
+	return SetLevels(update)
+}
+
+// componentHandler отбирает записи по уровню компонента и передает их текущему корневому обработчику.
+// Атрибуты и группы запоминаются и применяются при записи, поэтому смена формата в Configure
+// действует и на журналы, созданные раньше.
+type componentHandler struct {
+	component *component
+	apply     []func(slog.Handler) slog.Handler
+}
+
+func (h *componentHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.component.level.Level()
+}
+
+func (h *componentHandler) Handle(ctx context.Context, record slog.Record) error {
+	handler := *root.Load()
+	for _, apply := range h.apply {
+		handler = apply(handler)
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record = record.Clone()
+		record.AddAttrs(slog.String(KeyTraceID, span.TraceID().String()), slog.String(KeySpanID, span.SpanID().String()))
+	}
+	return handler.Handle(ctx, record)
+}
+
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h *componentHandler) with(apply func(slog.Handler) slog.Handler) slog.Handler {
+	return &componentHandler{
+		component: h.component,
+		apply:     append(append([]func(slog.Handler) slog.Handler(nil), h.apply...), apply),
+	}
+}
+
+// Session возвращает атрибуты сессии: sessionId, machineId и endpoint
+func Session(conn *entities.ConnectionInfo) slog.Attr {
+	return slog.Group("", KeySessionID, conn.SessionID, KeyMachineID, conn.MachineID, KeyEndpoint, conn.Config.EndpointURL)
+}
+
+// Err возвращает атрибут с текстом ошибки
+func Err(err error) slog.Attr {
+	return slog.String(KeyError, err.Error())
+}
+
+// ErrorClass возвращает атрибут класса ошибки, по которому записи удобно группировать
+func ErrorClass(class string) slog.Attr {
+	return slog.String(KeyErrorClass, class)
+}
@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// RepeatLimiter ограничивает запись повторяющихся ошибок: первая ошибка каждого ключа пишется сразу,
+// а повторы в течение интервала только подсчитываются. Ключи сгруппированы по области (например, сессии),
+// чтобы после восстановления можно было забыть все ошибки области разом.
+type RepeatLimiter struct {
+	interval time.Duration
+
+	mu     sync.Mutex
+	scopes map[string]map[string]*repeatState
+}
+
+type repeatState struct {
+	loggedAt   time.Time
+	suppressed int
+}
+
+// NewRepeatLimiter создает ограничитель; при интервале 0 и меньше записываются все ошибки
+func NewRepeatLimiter(interval time.Duration) *RepeatLimiter {
+	return &RepeatLimiter{interval: interval, scopes: make(map[string]map[string]*repeatState)}
+}
+
+// Allow сообщает, нужно ли записать ошибку, и сколько ее повторов подавлено с прошлой записи
+func (l *RepeatLimiter) Allow(scope, key string) (bool, int) {
+	if l.interval <= 0 {
+		return true, 0
+	}
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	keys, ok := l.scopes[scope]
+	if !ok {
+		keys = make(map[string]*repeatState)
+		l.scopes[scope] = keys
+	}
+	state, ok := keys[key]
+	if !ok {
+		keys[key] = &repeatState{loggedAt: now}
+		return true, 0
+	}
+	if now.Sub(state.loggedAt) < l.interval {
+		state.suppressed++
+		return false, 0
+	}
+	suppressed := state.suppressed
+	state.loggedAt, state.suppressed = now, 0
+	return true, suppressed
+}
+
+// Reset забывает ошибки области. Возвращает число подавленных, но еще не упомянутых в журнале повторов
+// и признак того, что у области были ошибки.
+func (l *RepeatLimiter) Reset(scope string) (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	keys, ok := l.scopes[scope]
+	if !ok {
+		return 0, false
+	}
+	suppressed := 0
+	for _, state := range keys {
+		suppressed += state.suppressed
+	}
+	delete(l.scopes, scope)
+	return suppressed, true
+}
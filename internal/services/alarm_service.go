@@ -0,0 +1,170 @@
+package services
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AlarmService отслеживает активные аварии каждой сессии и формирует события их жизненного цикла
+type AlarmService struct {
+	mu     sync.RWMutex
+	active map[string]map[string]*entities.ActiveAlarm // sessionID -> dataItemId|nativeCode -> авария
+}
+
+func NewAlarmService() interfaces.AlarmService {
+	return &AlarmService{
+		active: make(map[string]map[string]*entities.ActiveAlarm),
+	}
+}
+
+// ProcessMachineData сравнивает состояние условий нового снимка с реестром активных аварий
+// и возвращает события AlarmRaised, AlarmUpdated и AlarmCleared.
+func (s *AlarmService) ProcessMachineData(sessionID string, data entities.MachineData) []entities.AlarmEvent {
+	now := time.Now().UTC()
+	current := make(map[string]*entities.ActiveAlarm)
+	statesByItem := make(map[string]entities.ConditionState, len(data.Conditions))
+	for _, state := range data.Conditions {
+		statesByItem[state.DataItemId] = state
+		for _, activation := range state.Activations {
+			alarm := &entities.ActiveAlarm{
+				SessionID:      sessionID,
+				MachineId:      data.MachineId,
+				DataItemId:     state.DataItemId,
+				NativeCode:     activation.NativeCode,
+				NativeSeverity: activation.NativeSeverity,
+				Qualifier:      activation.Qualifier,
+				Level:          activation.Level,
+				Type:           state.Type,
+				ComponentId:    state.ComponentId,
+				ComponentName:  state.ComponentName,
+				Message:        activation.Message,
+				StartTime:      parseAgentTime(activation.Timestamp, now),
+				LastUpdated:    now,
+			}
+			current[alarmKey(alarm.DataItemId, alarm.NativeCode)] = alarm
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := s.active[sessionID]
+	var events []entities.AlarmEvent
+
+	for key, prev := range previous {
+		if _, still := current[key]; still {
+			continue
+		}
+		state, reported := statesByItem[prev.DataItemId]
+		if !reported {
+			// DataItem отсутствует в снимке: состояние неизвестно, оставляем аварию активной
+			current[key] = prev
+			continue
+		}
+		endTime := parseAgentTime(state.Timestamp, now)
+		if endTime.Before(prev.StartTime) {
+			endTime = now
+		}
+		reason := entities.ConditionNormal
+		if state.State == entities.ConditionUnavailable {
+			reason = entities.ConditionUnavailable
+		}
+		event := newAlarmEvent(entities.AlarmCleared, prev)
+		event.EndTime = &endTime
+		event.DurationMs = endTime.Sub(prev.StartTime).Milliseconds()
+		event.Reason = reason
+		events = append(events, event)
+	}
+
+	for key, alarm := range current {
+		prev, existed := previous[key]
+		switch {
+		case !existed:
+			events = append(events, newAlarmEvent(entities.AlarmRaised, alarm))
+		case prev == alarm:
+			// Авария перенесена из предыдущего состояния без изменений
+		case prev.Level != alarm.Level || prev.Message != alarm.Message ||
+			prev.Qualifier != alarm.Qualifier || prev.NativeSeverity != alarm.NativeSeverity:
+			alarm.StartTime = prev.StartTime
+			event := newAlarmEvent(entities.AlarmUpdated, alarm)
+			event.PreviousLevel = prev.Level
+			events = append(events, event)
+		default:
+			alarm.StartTime = prev.StartTime
+			alarm.LastUpdated = prev.LastUpdated
+		}
+	}
+
+	if len(current) == 0 {
+		delete(s.active, sessionID)
+	} else {
+		s.active[sessionID] = current
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartTime.Before(events[j].StartTime)
+	})
+	return events
+}
+
+// GetActiveAlarms возвращает активные аварии сессии, отсортированные по времени начала
+func (s *AlarmService) GetActiveAlarms(sessionID string) []entities.ActiveAlarm {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	alarms := make([]entities.ActiveAlarm, 0, len(s.active[sessionID]))
+	for _, alarm := range s.active[sessionID] {
+		alarms = append(alarms, *alarm)
+	}
+	sort.Slice(alarms, func(i, j int) bool {
+		if alarms[i].StartTime.Equal(alarms[j].StartTime) {
+			return alarmKey(alarms[i].DataItemId, alarms[i].NativeCode) < alarmKey(alarms[j].DataItemId, alarms[j].NativeCode)
+		}
+		return alarms[i].StartTime.Before(alarms[j].StartTime)
+	})
+	return alarms
+}
+
+// ClearSession удаляет реестр аварий сессии (например, при удалении подключения)
+func (s *AlarmService) ClearSession(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.active, sessionID)
+}
+
+func alarmKey(dataItemId, nativeCode string) string {
+	return dataItemId + "|" + nativeCode
+}
+
+func newAlarmEvent(eventType string, alarm *entities.ActiveAlarm) entities.AlarmEvent {
+	return entities.AlarmEvent{
+		EventType:      eventType,
+		SessionID:      alarm.SessionID,
+		MachineId:      alarm.MachineId,
+		DataItemId:     alarm.DataItemId,
+		NativeCode:     alarm.NativeCode,
+		NativeSeverity: alarm.NativeSeverity,
+		Qualifier:      alarm.Qualifier,
+		Level:          alarm.Level,
+		Type:           alarm.Type,
+		ComponentId:    alarm.ComponentId,
+		ComponentName:  alarm.ComponentName,
+		Message:        alarm.Message,
+		StartTime:      alarm.StartTime,
+	}
+}
+
+// parseAgentTime разбирает временную метку агента MTConnect, при ошибке возвращает fallback
+func parseAgentTime(timestamp string, fallback time.Time) time.Time {
+	if timestamp == "" {
+		return fallback
+	}
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return fallback
+	}
+	return t.UTC()
+}
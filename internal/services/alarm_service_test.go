@@ -0,0 +1,164 @@
+package services
+
+import (
+	"MTConnect/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+// conditionSnapshot формирует снимок станка с заданными состояниями условий
+func conditionSnapshot(states ...entities.ConditionState) entities.MachineData {
+	return entities.MachineData{MachineId: "Mazak", Conditions: states}
+}
+
+func conditionState(dataItemID, state, timestamp string, activations ...entities.ConditionActivation) entities.ConditionState {
+	return entities.ConditionState{DataItemId: dataItemID, Type: "SYSTEM", State: state, Timestamp: timestamp, Activations: activations}
+}
+
+func activation(code, level, message, timestamp string) entities.ConditionActivation {
+	return entities.ConditionActivation{NativeCode: code, Level: level, Message: message, Timestamp: timestamp}
+}
+
+// eventSummary - значимые поля события аварии для сравнения
+type eventSummary struct {
+	eventType, dataItemID, nativeCode, level, previousLevel, reason string
+}
+
+func summarize(events []entities.AlarmEvent) map[eventSummary]entities.AlarmEvent {
+	summaries := make(map[eventSummary]entities.AlarmEvent, len(events))
+	for _, event := range events {
+		summaries[eventSummary{event.EventType, event.DataItemId, event.NativeCode, event.Level, event.PreviousLevel, event.Reason}] = event
+	}
+	return summaries
+}
+
+func TestAlarmServiceLifecycle(t *testing.T) {
+	const (
+		t1 = "2024-01-01T00:00:01Z"
+		t2 = "2024-01-01T00:00:02Z"
+		t3 = "2024-01-01T00:00:03Z"
+		t4 = "2024-01-01T00:00:10Z"
+		t5 = "2024-01-01T00:01:01Z"
+	)
+	fault, warning := entities.ConditionFault, entities.ConditionWarning
+	steps := []struct {
+		name     string
+		snapshot entities.MachineData
+		want     []eventSummary
+	}{
+		{
+			name:     "первая авария",
+			snapshot: conditionSnapshot(conditionState("sys", fault, t1, activation("E1", fault, "Overheat", t1))),
+			want:     []eventSummary{{entities.AlarmRaised, "sys", "E1", fault, "", ""}},
+		},
+		{
+			name: "второй код того же DataItem и тот же код другого DataItem",
+			snapshot: conditionSnapshot(
+				conditionState("sys", fault, t2, activation("E1", fault, "Overheat", t1), activation("E2", warning, "Low oil", t2)),
+				conditionState("hw", fault, t2, activation("E1", fault, "Drive", t2)),
+			),
+			want: []eventSummary{
+				{entities.AlarmRaised, "sys", "E2", warning, "", ""},
+				{entities.AlarmRaised, "hw", "E1", fault, "", ""},
+			},
+		},
+		{
+			name: "изменение уровня",
+			snapshot: conditionSnapshot(
+				conditionState("sys", warning, t3, activation("E1", warning, "Overheat", t3), activation("E2", warning, "Low oil", t2)),
+				conditionState("hw", fault, t2, activation("E1", fault, "Drive", t2)),
+			),
+			want: []eventSummary{{entities.AlarmUpdated, "sys", "E1", warning, fault, ""}},
+		},
+		{
+			name: "снятие одного кода",
+			snapshot: conditionSnapshot(
+				conditionState("sys", warning, t4, activation("E1", warning, "Overheat", t3)),
+				conditionState("hw", fault, t2, activation("E1", fault, "Drive", t2)),
+			),
+			want: []eventSummary{{entities.AlarmCleared, "sys", "E2", warning, "", entities.ConditionNormal}},
+		},
+		{
+			name:     "DataItem отсутствует в снимке",
+			snapshot: conditionSnapshot(conditionState("hw", fault, t2, activation("E1", fault, "Drive", t2))),
+		},
+		{
+			name: "источник условий недоступен",
+			snapshot: conditionSnapshot(
+				conditionState("sys", entities.ConditionUnavailable, t5),
+				conditionState("hw", entities.ConditionNormal, t5),
+			),
+			want: []eventSummary{
+				{entities.AlarmCleared, "sys", "E1", warning, "", entities.ConditionUnavailable},
+				{entities.AlarmCleared, "hw", "E1", fault, "", entities.ConditionNormal},
+			},
+		},
+	}
+
+	s := NewAlarmService().(*AlarmService)
+	for _, step := range steps {
+		events := summarize(s.ProcessMachineData("s1", step.snapshot))
+		if len(events) != len(step.want) {
+			t.Errorf("%s: события %v, ожидалось %v", step.name, events, step.want)
+			continue
+		}
+		for _, want := range step.want {
+			if _, ok := events[want]; !ok {
+				t.Errorf("%s: нет события %+v среди %v", step.name, want, events)
+			}
+		}
+
+		switch step.name {
+		case "изменение уровня":
+			updated := events[step.want[0]]
+			if !updated.StartTime.Equal(mustParseTime(t, t1)) {
+				t.Errorf("обновление аварии изменило время начала: %v", updated.StartTime)
+			}
+		case "снятие одного кода":
+			cleared := events[step.want[0]]
+			if cleared.EndTime == nil || !cleared.EndTime.Equal(mustParseTime(t, t4)) || cleared.DurationMs != 8000 {
+				t.Errorf("снятие аварии: EndTime = %v, DurationMs = %d, ожидалось %s и 8000", cleared.EndTime, cleared.DurationMs, t4)
+			}
+		case "DataItem отсутствует в снимке":
+			if active := s.GetActiveAlarms("s1"); len(active) != 2 {
+				t.Errorf("активных аварий: %d, ожидалось 2 - состояние отсутствующего DataItem неизвестно", len(active))
+			}
+		}
+	}
+	if active := s.GetActiveAlarms("s1"); len(active) != 0 {
+		t.Errorf("после снятия всех аварий остались активные: %v", active)
+	}
+}
+
+func TestAlarmServiceClearSession(t *testing.T) {
+	const raised, normal = "2024-01-01T00:00:01Z", "2024-01-01T00:00:05Z"
+	faulty := conditionSnapshot(conditionState("sys", entities.ConditionFault, raised, activation("E1", entities.ConditionFault, "Overheat", raised)))
+
+	s := NewAlarmService().(*AlarmService)
+	s.ProcessMachineData("s1", faulty)
+	s.ProcessMachineData("s2", faulty)
+
+	// Подключение удалено или изменено: аварии старой сессии забываются без события снятия
+	s.ClearSession("s1")
+	if active := s.GetActiveAlarms("s1"); len(active) != 0 {
+		t.Fatalf("после ClearSession остались активные аварии: %v", active)
+	}
+	if events := s.ProcessMachineData("s1", conditionSnapshot(conditionState("sys", entities.ConditionNormal, normal))); len(events) != 0 {
+		t.Errorf("после ClearSession сформированы устаревшие события: %v", events)
+	}
+	if events := s.ProcessMachineData("s1", faulty); len(events) != 1 || events[0].EventType != entities.AlarmRaised {
+		t.Errorf("авария после ClearSession должна считаться новой: %v", events)
+	}
+	if active := s.GetActiveAlarms("s2"); len(active) != 1 {
+		t.Errorf("ClearSession затронул другую сессию: активных аварий %d", len(active))
+	}
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var auditLog = logging.Logger(logging.ComponentAudit)
+
+// AuditLogService дописывает записи аудита в файл JSON Lines и дублирует их в лог сервиса
+type AuditLogService struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewAuditLogService(cfg *config.AppConfig) (interfaces.AuditLog, error) {
+	path := cfg.Auth.AuditLog
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("не удалось создать каталог журнала аудита: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть журнал аудита %s: %w", path, err)
+	}
+	return &AuditLogService{file: file}, nil
+}
+
+func (a *AuditLogService) Record(record entities.AuditRecord) {
+	auditLog.Info("аудит", "subject", record.Subject, "role", record.Role, "action", record.Action, "result", record.Result)
+	line, err := json.Marshal(record)
+	if err != nil {
+		auditLog.Error("не удалось сериализовать запись аудита", logging.Err(err))
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return
+	}
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		auditLog.Error("не удалось записать журнал аудита", logging.Err(err))
+	}
+}
+
+func (a *AuditLogService) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
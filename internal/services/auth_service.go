@@ -0,0 +1,238 @@
+package services
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var authLog = logging.Logger(logging.ComponentAuth)
+
+// AuthService проверяет API-ключи и JWT. Ключи хранятся в виде SHA-256, токены проверяются локально
+// по общему секрету или открытому ключу без обращения к внешнему серверу авторизации.
+type AuthService struct {
+	enabled   bool
+	keys      map[[sha256.Size]byte]entities.Principal
+	parser    *jwt.Parser
+	secret    []byte
+	publicKey interface{}
+	roleClaim string
+}
+
+func NewAuthService(cfg *config.AppConfig) (interfaces.Authenticator, error) {
+	authCfg := cfg.Auth
+	s := &AuthService{
+		enabled:   authCfg.Enabled,
+		keys:      make(map[[sha256.Size]byte]entities.Principal),
+		roleClaim: authCfg.JWT.RoleClaim,
+	}
+	if !s.enabled {
+		authLog.Warn("аутентификация API выключена, все запросы выполняются с ролью admin")
+		return s, nil
+	}
+
+	keys := authCfg.APIKeys
+	if authCfg.KeysFile != "" {
+		fileKeys, err := loadKeysFile(authCfg.KeysFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(append([]config.APIKeyConfig{}, keys...), fileKeys...)
+	}
+	for i, key := range keys {
+		if err := s.addKey(key); err != nil {
+			return nil, fmt.Errorf("ключ API #%d (%s): %w", i+1, key.Name, err)
+		}
+	}
+
+	if err := s.configureJWT(authCfg.JWT); err != nil {
+		return nil, err
+	}
+	if len(s.keys) == 0 && s.parser == nil {
+		return nil, fmt.Errorf("аутентификация включена, но не заданы ни ключи API, ни параметры проверки JWT")
+	}
+	authLog.Info("аутентификация API включена", "apiKeys", len(s.keys), "jwt", s.parser != nil)
+	return s, nil
+}
+
+func (s *AuthService) Enabled() bool {
+	return s.enabled
+}
+
+func (s *AuthService) Authenticate(apiKey, bearerToken string) (entities.Principal, error) {
+	if !s.enabled {
+		return entities.Principal{Subject: "anonymous", Role: entities.RoleAdmin, AuthMethod: entities.AuthMethodNone}, nil
+	}
+	switch {
+	case apiKey != "":
+		principal, ok := s.keys[sha256.Sum256([]byte(apiKey))]
+		if !ok {
+			return entities.Principal{}, entities.NewError(entities.ErrorCodeUnauthenticated, "неизвестный ключ API")
+		}
+		return principal, nil
+	case bearerToken != "":
+		return s.authenticateJWT(bearerToken)
+	}
+	return entities.Principal{}, entities.NewError(entities.ErrorCodeUnauthenticated, "требуется ключ API (X-API-Key) или JWT (Authorization: Bearer)")
+}
+
+func (s *AuthService) addKey(key config.APIKeyConfig) error {
+	role, ok := entities.ParseRole(key.Role)
+	if !ok {
+		return fmt.Errorf("неизвестная роль '%s', ожидается viewer, operator или admin", key.Role)
+	}
+	if key.Name == "" {
+		return fmt.Errorf("не задано имя владельца ключа")
+	}
+	var hash [sha256.Size]byte
+	switch {
+	case key.Key != "":
+		hash = sha256.Sum256([]byte(key.Key))
+	case key.KeySHA256 != "":
+		decoded, err := hex.DecodeString(key.KeySHA256)
+		if err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("key_sha256 должен содержать SHA-256 в hex")
+		}
+		copy(hash[:], decoded)
+	default:
+		return fmt.Errorf("не задан key или key_sha256")
+	}
+	if _, exists := s.keys[hash]; exists {
+		return fmt.Errorf("ключ уже используется другим владельцем")
+	}
+	s.keys[hash] = entities.Principal{Subject: key.Name, Role: role, AuthMethod: entities.AuthMethodAPIKey}
+	return nil
+}
+
+func (s *AuthService) configureJWT(cfg config.JWTConfig) error {
+	var methods []string
+	if cfg.Secret != "" {
+		s.secret = []byte(cfg.Secret)
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if cfg.PublicKeyFile != "" {
+		key, keyMethods, err := loadPublicKey(cfg.PublicKeyFile)
+		if err != nil {
+			return err
+		}
+		s.publicKey = key
+		methods = append(methods, keyMethods...)
+	}
+	if len(methods) == 0 {
+		return nil
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Duration(cfg.LeewaySec) * time.Second),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	s.parser = jwt.NewParser(options...)
+	return nil
+}
+
+func (s *AuthService) authenticateJWT(tokenString string) (entities.Principal, error) {
+	if s.parser == nil {
+		return entities.Principal{}, entities.NewError(entities.ErrorCodeUnauthenticated, "проверка JWT не настроена")
+	}
+	claims := jwt.MapClaims{}
+	_, err := s.parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		// Список допустимых алгоритмов уже проверен парсером
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			return s.secret, nil
+		}
+		return s.publicKey, nil
+	})
+	if err != nil {
+		return entities.Principal{}, entities.NewError(entities.ErrorCodeUnauthenticated, "JWT не прошел проверку: %w", err)
+	}
+
+	role, ok := roleFromClaim(claims[s.roleClaim])
+	if !ok {
+		return entities.Principal{}, entities.NewError(entities.ErrorCodePermissionDenied, "JWT не содержит допустимой роли в claim '%s'", s.roleClaim)
+	}
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		subject = "jwt"
+	}
+	return entities.Principal{Subject: subject, Role: role, AuthMethod: entities.AuthMethodJWT}, nil
+}
+
+// roleFromClaim выбирает старшую из известных ролей claim, заданного строкой или массивом строк
+func roleFromClaim(value interface{}) (entities.Role, bool) {
+	var candidates []string
+	switch v := value.(type) {
+	case string:
+		candidates = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				candidates = append(candidates, str)
+			}
+		}
+	}
+	var best entities.Role
+	for _, candidate := range candidates {
+		if role, ok := entities.ParseRole(candidate); ok && (best == "" || role.Allows(best)) {
+			best = role
+		}
+	}
+	return best, best != ""
+}
+
+func loadKeysFile(path string) ([]config.APIKeyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать файл ключей API %s: %w", path, err)
+	}
+	var keys []config.APIKeyConfig
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать файл ключей API %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// loadPublicKey читает открытый ключ PEM (PKIX) и возвращает подходящие для него алгоритмы подписи
+func loadPublicKey(path string) (interface{}, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("не удалось прочитать открытый ключ JWT %s: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, fmt.Errorf("файл %s не содержит ключ в формате PEM", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("не удалось разобрать открытый ключ JWT %s: %w", path, err)
+	}
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return key, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}, nil
+	case *ecdsa.PublicKey:
+		return key, []string{"ES256", "ES384", "ES512"}, nil
+	case ed25519.PublicKey:
+		return key, []string{"EdDSA"}, nil
+	}
+	return nil, nil, fmt.Errorf("тип открытого ключа %T не поддерживается", key)
+}
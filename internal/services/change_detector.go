@@ -0,0 +1,92 @@
+package services
+
+import (
+	"MTConnect/internal/config"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ignoredChangePaths - поля, изменение которых само по себе не считается изменением данных
+var ignoredChangePaths = map[string]bool{
+	"Timestamp": true,
+}
+
+// ChangeDetector сравнивает плоские представления MachineData с учетом зон нечувствительности
+type ChangeDetector struct {
+	deadbands map[string]float64
+}
+
+func NewChangeDetector(cfg config.PublishConfig) *ChangeDetector {
+	return &ChangeDetector{deadbands: cfg.Deadbands}
+}
+
+// Diff возвращает изменившиеся поля и пути удаленных полей.
+// Числовые значения считаются изменившимися, только если отклонение превышает зону нечувствительности.
+func (d *ChangeDetector) Diff(previous, current map[string]interface{}) (map[string]interface{}, []string) {
+	changed := make(map[string]interface{})
+	var removed []string
+
+	for path, value := range current {
+		if ignoredChangePaths[path] {
+			continue
+		}
+		prev, existed := previous[path]
+		if !existed || d.isChanged(path, prev, value) {
+			changed[path] = value
+		}
+	}
+	for path := range previous {
+		if ignoredChangePaths[path] {
+			continue
+		}
+		if _, exists := current[path]; !exists {
+			removed = append(removed, path)
+		}
+	}
+	sort.Strings(removed)
+	return changed, removed
+}
+
+func (d *ChangeDetector) isChanged(path string, previous, current interface{}) bool {
+	prevNum, prevOk := toFloat(previous)
+	currNum, currOk := toFloat(current)
+	if prevOk && currOk {
+		return math.Abs(currNum-prevNum) > d.deadbandFor(path)
+	}
+	return !reflect.DeepEqual(previous, current)
+}
+
+// deadbandFor ищет зону нечувствительности: полный путь, поле верхнего уровня, тип данных, "*"
+func (d *ChangeDetector) deadbandFor(path string) float64 {
+	if len(d.deadbands) == 0 {
+		return 0
+	}
+	if v, ok := d.deadbands[path]; ok {
+		return v
+	}
+	segments := strings.Split(path, ".")
+	if v, ok := d.deadbands[segments[0]]; ok {
+		return v
+	}
+	if v, ok := d.deadbands[segments[len(segments)-1]]; ok {
+		return v
+	}
+	if v, ok := d.deadbands["*"]; ok {
+		return v
+	}
+	return 0
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
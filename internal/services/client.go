@@ -1,36 +1,116 @@
 package services
 
 import (
+	"MTConnect/internal/domain/entities"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Классы ошибок получения ответа агента для показателей
+const (
+	FetchErrorTimeout    = "timeout"
+	FetchErrorConnection = "connection"
+	FetchErrorHTTPStatus = "http_status"
+	FetchErrorRead       = "read"
+	FetchErrorParse      = "parse"
+	FetchErrorOther      = "other"
 )
 
+// HTTPStatusError - агент ответил статусом, отличным от 200 OK
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return e.Status
+}
+
+// bodyReadError - ошибка чтения тела ответа агента
+type bodyReadError struct {
+	err error
+}
+
+func (e *bodyReadError) Error() string {
+	return e.err.Error()
+}
+
+func (e *bodyReadError) Unwrap() error {
+	return e.err
+}
+
 // FetchXML выполняет GET-запрос к указанному URL, запрашивая XML
 func FetchXML(url string) ([]byte, error) {
+	return FetchXMLContext(context.Background(), url)
+}
+
+// FetchXMLContext выполняет GET-запрос в спане FetchXML и передает агенту контекст трассировки
+func FetchXMLContext(ctx context.Context, url string) ([]byte, error) {
+	ctx, span := tracer().Start(ctx, "FetchXML", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(semconv.URLFull(url)))
+	defer span.End()
+
+	body, err := fetchXML(ctx, url)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("mtconnect.response_bytes", len(body)))
+	return body, nil
+}
+
+func fetchXML(ctx context.Context, url string) ([]byte, error) {
 	client := &http.Client{}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка создания запроса к %s: %w", url, err)
 	}
 
 	req.Header.Set("Accept", "application/xml")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка выполнения запроса к %s: %w", url, err)
+		return nil, entities.NewError(entities.ErrorCodeAgentUnreachable, "ошибка выполнения запроса к %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("сервер %s ответил со статусом %s", url, resp.Status)
+		return nil, entities.NewError(entities.ErrorCodeAgentUnreachable, "сервер %s ответил со статусом %w", url, &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status})
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка чтения ответа от %s: %w", url, err)
+		return nil, entities.NewError(entities.ErrorCodeAgentUnreachable, "ошибка чтения ответа от %s: %w", url, &bodyReadError{err: err})
 	}
 
 	return body, nil
 }
+
+// FetchErrorKind относит ошибку FetchXML к одному из классов FetchError*
+func FetchErrorKind(err error) string {
+	var statusErr *HTTPStatusError
+	var readErr *bodyReadError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &statusErr):
+		return FetchErrorHTTPStatus
+	case errors.As(err, &readErr):
+		return FetchErrorRead
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return FetchErrorTimeout
+	case entities.ErrorCodeOf(err) == entities.ErrorCodeAgentUnreachable:
+		return FetchErrorConnection
+	}
+	return FetchErrorOther
+}
@@ -3,9 +3,10 @@ package services
 import (
 	"MTConnect/internal/domain/entities"
 	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
 	"encoding/xml"
 	"fmt"
-	"log"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +14,8 @@ import (
 	"github.com/google/uuid"
 )
 
+var connectionsLog = logging.Logger(logging.ComponentConnections)
+
 type ConnectionService struct {
 	mu         sync.RWMutex
 	pool       map[string]*entities.ConnectionInfo
@@ -28,62 +31,38 @@ func NewConnectionService(pollingSvc interfaces.PollingService) interfaces.Conne
 
 // CreateConnection проверяет новый запрос на подключение и добавляет его в пул.
 func (s *ConnectionService) CreateConnection(req entities.ConnectionRequest) (*entities.ConnectionInfo, error) {
-	s.mu.RLock()
-	for _, conn := range s.pool {
-		if conn.Config.EndpointURL == req.EndpointURL && conn.Config.Model == req.Model {
-			s.mu.RUnlock()
-			return nil, fmt.Errorf("подключение для модели '%s' на эндпоинте '%s' уже существует с SessionID: %s", req.Model, req.EndpointURL, conn.SessionID)
-		}
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
-	s.mu.RUnlock()
-
-	probeURL := strings.TrimSuffix(req.EndpointURL, "/") + "/probe"
 
-	xmlData, err := FetchXML(probeURL)
+	targetDevice, err := s.findDevice(req.EndpointURL, req.Model, req.Manufacturer, req.Match)
 	if err != nil {
-		return nil, fmt.Errorf("не удалось получить /probe с %s: %w", probeURL, err)
-	}
-
-	var devices entities.MTConnectDevices
-	if err := xml.Unmarshal(xmlData, &devices); err != nil {
-		return nil, fmt.Errorf("не удалось распарсить /probe XML с %s: %w", probeURL, err)
+		return nil, err
 	}
 
-	if len(devices.Devices) == 0 {
-		return nil, fmt.Errorf("устройства не найдены в /probe ответе от %s", probeURL)
-	}
-
-	var targetDevice *entities.Device
-	for i := range devices.Devices {
-		device := devices.Devices[i]
-		if device.Description == nil {
-			continue
-		}
-
-		replacer := strings.NewReplacer("\n", " ", "\t", " ", "\r", " ")
-		cleanedDescription := replacer.Replace(device.Description.Value)
-		normalizedDescription := strings.Join(strings.Fields(cleanedDescription), " ")
-
-		if strings.Contains(normalizedDescription, req.Model) {
-			targetDevice = &device
-			break
-		}
+	if err := s.pollingSvc.LoadMetadataForEndpoint(req.EndpointURL); err != nil {
+		return nil, fmt.Errorf("ошибка при загрузке метаданных для %s: %w", req.EndpointURL, err)
 	}
 
-	if targetDevice == nil {
-		return nil, fmt.Errorf("устройство с моделью '%s' не найдено на эндпоинте %s", req.Model, req.EndpointURL)
-	}
+	return s.addConnection(req, targetDevice)
+}
 
-	if req.Manufacturer != "" && !strings.EqualFold(targetDevice.Description.Manufacturer, req.Manufacturer) {
-		return nil, fmt.Errorf("производитель '%s' не совпадает с указанным в /probe для найденной модели: '%s'", req.Manufacturer, targetDevice.Description.Manufacturer)
+// addConnection добавляет в пул подключение к найденному устройству и запускает для него опрос,
+// если глобальный опрос активен. Дубликатом считается подключение к тому же устройству агента;
+// проверка выполняется под блокировкой, чтобы параллельные запросы не подключили устройство дважды.
+func (s *ConnectionService) addConnection(req entities.ConnectionRequest, targetDevice *entities.Device) (*entities.ConnectionInfo, error) {
+	if targetDevice.Description != nil {
+		req.Manufacturer = targetDevice.Description.Manufacturer
 	}
-
-	if err := s.pollingSvc.LoadMetadataForEndpoint(req.EndpointURL); err != nil {
-		return nil, fmt.Errorf("ошибка при загрузке метаданных для %s: %w", req.EndpointURL, err)
+	if req.Model == "" {
+		req.Model = deviceModel(targetDevice)
 	}
 
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	if conn := s.findDuplicateUnsafe(req.EndpointURL, targetDevice.Name, ""); conn != nil {
+		s.mu.Unlock()
+		return nil, duplicateError(req.EndpointURL, targetDevice.Name, conn.SessionID)
+	}
 
 	sessionID := uuid.New().String()
 	connInfo := &entities.ConnectionInfo{
@@ -92,7 +71,8 @@ func (s *ConnectionService) CreateConnection(req entities.ConnectionRequest) (*e
 		Config: entities.ConnectionConfig{
 			EndpointURL:  req.EndpointURL,
 			Model:        req.Model,
-			Manufacturer: targetDevice.Description.Manufacturer,
+			Manufacturer: req.Manufacturer,
+			Match:        req.Match,
 		},
 		CreatedAt: time.Now(),
 		LastUsed:  time.Now(),
@@ -101,19 +81,459 @@ func (s *ConnectionService) CreateConnection(req entities.ConnectionRequest) (*e
 	}
 
 	s.pool[sessionID] = connInfo
+	s.mu.Unlock()
+	s.pollingSvc.PublishLifecycleEvent(connInfo, entities.LifecycleConnectionCreated, "", map[string]string{"model": req.Model})
 
-	// --- ИЗМЕНЕНИЕ ЗДЕСЬ ---
 	// После успешного добавления подключения в пул,
 	// пытаемся запустить для него опрос, если глобальный опрос активен.
 	if err := s.pollingSvc.StartPollingForNewConnectionIfNeeded(connInfo); err != nil {
 		// Эта ошибка не должна откатывать создание подключения,
 		// но ее стоит залогировать.
-		log.Printf("ПРЕДУПРЕЖДЕНИЕ: не удалось автоматически запустить опрос для новой сессии %s: %v", connInfo.SessionID, err)
+		connectionsLog.Warn("не удалось запустить опрос для новой сессии", logging.Session(connInfo), logging.Err(err))
 	}
 
 	return connInfo, nil
 }
 
+// CreateConnections создает подключения пакетом. Запросы группируются по эндпоинту: /probe и метаданные
+// каждого агента загружаются один раз, агенты обрабатываются параллельно, не более concurrency одновременно.
+// Запросы одного агента выполняются по порядку, поэтому из повторов в пакете создается первый.
+// Результаты возвращаются в порядке запросов.
+func (s *ConnectionService) CreateConnections(reqs []entities.ConnectionRequest, concurrency int) []entities.BulkConnectionResult {
+	results := make([]entities.BulkConnectionResult, len(reqs))
+	groups := make(map[string][]int)
+	var endpoints []string
+	for i, req := range reqs {
+		results[i] = entities.BulkConnectionResult{Index: i, Request: req}
+		if err := req.Validate(); err != nil {
+			results[i].SetError(err)
+			continue
+		}
+		if _, exists := groups[req.EndpointURL]; !exists {
+			endpoints = append(endpoints, req.EndpointURL)
+		}
+		groups[req.EndpointURL] = append(groups[req.EndpointURL], i)
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, endpointURL := range endpoints {
+		wg.Add(1)
+		go func(endpointURL string, indexes []int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			s.createForEndpoint(endpointURL, indexes, results)
+		}(endpointURL, groups[endpointURL])
+	}
+	wg.Wait()
+	return results
+}
+
+// createForEndpoint создает подключения пакета, относящиеся к одному агенту
+func (s *ConnectionService) createForEndpoint(endpointURL string, indexes []int, results []entities.BulkConnectionResult) {
+	devices, err := fetchDevices(endpointURL)
+	if err == nil {
+		if metaErr := s.pollingSvc.LoadMetadataForEndpoint(endpointURL); metaErr != nil {
+			err = fmt.Errorf("ошибка при загрузке метаданных для %s: %w", endpointURL, metaErr)
+		}
+	}
+	for _, i := range indexes {
+		if err != nil {
+			results[i].SetError(err)
+			continue
+		}
+		req := results[i].Request
+		targetDevice, matchErr := matchDevice(devices, endpointURL, req.Model, req.Manufacturer, req.Match)
+		if matchErr != nil {
+			results[i].SetError(matchErr)
+			continue
+		}
+		connInfo, addErr := s.addConnection(req, targetDevice)
+		if addErr != nil {
+			results[i].SetError(addErr)
+			continue
+		}
+		results[i].Connection = connInfo
+	}
+}
+
+// UpdateConnection применяет изменения к подключению: новая конфигурация заново проверяется по /probe,
+// опрос сессии перезапускается с новыми параметрами. SessionID и счетчики подключения сохраняются.
+func (s *ConnectionService) UpdateConnection(sessionID string, patch entities.ConnectionPatch) (*entities.ConnectionInfo, error) {
+	s.mu.RLock()
+	current, exists := s.pool[sessionID]
+	if !exists {
+		s.mu.RUnlock()
+		return nil, entities.NewError(entities.ErrorCodeNotFound, "сессия '%s' не найдена", sessionID)
+	}
+	config := current.Config
+	s.mu.RUnlock()
+
+	if patch.EndpointURL != nil {
+		config.EndpointURL = *patch.EndpointURL
+	}
+	if patch.Model != nil {
+		config.Model = *patch.Model
+	}
+	if patch.Match != nil {
+		config.Match = patch.Match
+		if patch.Match.IsEmpty() {
+			config.Match = nil
+		}
+	}
+	// Производитель проверяется, только если он указан в изменениях; иначе берется из /probe
+	manufacturer := ""
+	if patch.Manufacturer != nil {
+		manufacturer = *patch.Manufacturer
+	}
+	request := entities.ConnectionRequest{EndpointURL: config.EndpointURL, Model: config.Model, Match: config.Match}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	targetDevice, err := s.findDevice(config.EndpointURL, config.Model, manufacturer, config.Match)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.pollingSvc.LoadMetadataForEndpoint(config.EndpointURL); err != nil {
+		return nil, fmt.Errorf("ошибка при загрузке метаданных для %s: %w", config.EndpointURL, err)
+	}
+	config.Manufacturer = ""
+	if targetDevice.Description != nil {
+		config.Manufacturer = targetDevice.Description.Manufacturer
+	}
+	// Модель из селектора подставляется заново: прежняя могла относиться к другому устройству
+	if config.Match != nil && patch.Model == nil {
+		config.Model = deviceModel(targetDevice)
+	}
+
+	s.mu.Lock()
+	current, exists = s.pool[sessionID]
+	if !exists {
+		s.mu.Unlock()
+		return nil, entities.NewError(entities.ErrorCodeNotFound, "сессия '%s' не найдена", sessionID)
+	}
+	if conn := s.findDuplicateUnsafe(config.EndpointURL, targetDevice.Name, sessionID); conn != nil {
+		s.mu.Unlock()
+		return nil, duplicateError(config.EndpointURL, targetDevice.Name, conn.SessionID)
+	}
+	// Опрос держит указатель на прежнее подключение, поэтому в пул помещается измененная копия
+	updated := *current
+	updated.MachineID = targetDevice.Name
+	updated.Config = config
+	updated.LastUsed = time.Now()
+	updated.IsHealthy = true
+	_ = s.pollingSvc.StopPollingForMachine(sessionID)
+	s.pool[sessionID] = &updated
+	s.mu.Unlock()
+
+	attributes := map[string]string{"model": config.Model}
+	if current.Config.EndpointURL != config.EndpointURL {
+		attributes["previousEndpointUrl"] = current.Config.EndpointURL
+	}
+	if current.MachineID != updated.MachineID {
+		attributes["previousMachineId"] = current.MachineID
+	}
+	s.pollingSvc.PublishLifecycleEvent(&updated, entities.LifecycleConnectionUpdated, "", attributes)
+
+	if err := s.pollingSvc.StartPollingForNewConnectionIfNeeded(&updated); err != nil {
+		connectionsLog.Warn("не удалось перезапустить опрос после изменения подключения", logging.Session(&updated), logging.Err(err))
+	}
+	return &updated, nil
+}
+
+// findDuplicateUnsafe ищет другое подключение к устройству machineID того же агента; вызывается под s.mu
+func (s *ConnectionService) findDuplicateUnsafe(endpointURL, machineID, exceptSessionID string) *entities.ConnectionInfo {
+	for _, conn := range s.pool {
+		if conn.SessionID != exceptSessionID && conn.MachineID == machineID && strings.TrimSuffix(conn.Config.EndpointURL, "/") == strings.TrimSuffix(endpointURL, "/") {
+			return conn
+		}
+	}
+	return nil
+}
+
+func duplicateError(endpointURL, machineID, sessionID string) error {
+	return entities.NewError(entities.ErrorCodeAlreadyExists, "подключение к устройству '%s' на эндпоинте '%s' уже существует с SessionID: %s", machineID, endpointURL, sessionID)
+}
+
+// DiscoverDevices возвращает все устройства из /probe агента с кратким описанием их состава
+func (s *ConnectionService) DiscoverDevices(endpointURL string) ([]entities.DiscoveredDevice, error) {
+	devices, err := fetchDevices(endpointURL)
+	if err != nil {
+		return nil, err
+	}
+	discovered := make([]entities.DiscoveredDevice, 0, len(devices))
+	for i := range devices {
+		device := summarizeDevice(&devices[i])
+		if conn := s.findDeviceConnection(endpointURL, device.Name); conn != nil {
+			device.SessionID = conn.SessionID
+		}
+		discovered = append(discovered, device)
+	}
+	return discovered, nil
+}
+
+// ConnectDevices создает подключения для выбранных устройств агента (по имени, UUID или id; пустой
+// список - все устройства). /probe запрашивается один раз, устройства регистрируются без поиска по модели;
+// в конфигурацию записывается селектор по UUID (или имени), чтобы экспорт выбирал то же устройство.
+func (s *ConnectionService) ConnectDevices(endpointURL string, selectors []string) ([]entities.BulkConnectionResult, error) {
+	devices, err := fetchDevices(endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*entities.Device
+	var missing []string
+	if len(selectors) == 0 {
+		for i := range devices {
+			selected = append(selected, &devices[i])
+		}
+	} else {
+		for _, selector := range selectors {
+			device := findDeviceByIdentity(devices, selector)
+			if device == nil {
+				missing = append(missing, selector)
+				continue
+			}
+			selected = append(selected, device)
+		}
+	}
+	if err := s.pollingSvc.LoadMetadataForEndpoint(endpointURL); err != nil {
+		return nil, fmt.Errorf("ошибка при загрузке метаданных для %s: %w", endpointURL, err)
+	}
+
+	results := make([]entities.BulkConnectionResult, 0, len(selected)+len(missing))
+	for _, device := range selected {
+		req := entities.ConnectionRequest{EndpointURL: endpointURL, Model: deviceModel(device), Match: &entities.DeviceSelector{UUID: device.UUID}}
+		if device.UUID == "" {
+			req.Match = &entities.DeviceSelector{Name: device.Name}
+		}
+		if device.Description != nil {
+			req.Manufacturer = device.Description.Manufacturer
+		}
+		result := entities.BulkConnectionResult{Index: len(results), Request: req}
+		if connInfo, err := s.addConnection(req, device); err != nil {
+			result.SetError(err)
+		} else {
+			result.Connection = connInfo
+		}
+		results = append(results, result)
+	}
+	for _, selector := range missing {
+		result := entities.BulkConnectionResult{Index: len(results), Request: entities.ConnectionRequest{EndpointURL: endpointURL}}
+		result.SetError(entities.NewError(entities.ErrorCodeNotFound, "устройство '%s' не найдено на эндпоинте %s", selector, endpointURL))
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// findDeviceConnection ищет подключение к устройству с именем machineID на эндпоинте
+func (s *ConnectionService) findDeviceConnection(endpointURL, machineID string) *entities.ConnectionInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.findDuplicateUnsafe(endpointURL, machineID, "")
+}
+
+// findDeviceByIdentity ищет устройство по имени, UUID или id
+func findDeviceByIdentity(devices []entities.Device, selector string) *entities.Device {
+	for i := range devices {
+		if devices[i].Name == selector || devices[i].UUID == selector || devices[i].ID == selector {
+			return &devices[i]
+		}
+	}
+	return nil
+}
+
+// deviceModel возвращает модель устройства для конфигурации подключения: атрибут model тега
+// Description, а если его нет - текст описания или имя устройства
+func deviceModel(device *entities.Device) string {
+	if device.Description != nil {
+		if device.Description.Model != "" {
+			return device.Description.Model
+		}
+		if text := normalizeText(device.Description.Value); text != "" {
+			return text
+		}
+	}
+	return device.Name
+}
+
+// summarizeDevice формирует описание устройства для ответа discovery
+func summarizeDevice(device *entities.Device) entities.DiscoveredDevice {
+	summary := entities.DiscoveredDevice{
+		Name:       device.Name,
+		UUID:       device.UUID,
+		ID:         device.ID,
+		Components: make(map[string]int),
+		DataItems:  len(device.DataItems),
+	}
+	if device.Description != nil {
+		summary.Manufacturer = device.Description.Manufacturer
+		summary.Model = device.Description.Model
+		summary.SerialNumber = device.Description.SerialNumber
+		summary.Description = normalizeText(device.Description.Value)
+	}
+	if device.ComponentList != nil {
+		countComponents(device.ComponentList.Components, &summary)
+	}
+	return summary
+}
+
+func countComponents(components []entities.ProbeComponent, summary *entities.DiscoveredDevice) {
+	for _, component := range components {
+		summary.Components[component.XMLName.Local]++
+		summary.DataItems += len(component.DataItems)
+		if component.ComponentList != nil {
+			countComponents(component.ComponentList.Components, summary)
+		}
+	}
+}
+
+// normalizeText заменяет переводы строк и табуляции пробелами и схлопывает повторяющиеся пробелы
+func normalizeText(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// findDevice запрашивает /probe агента и выбирает устройство по селектору или модели (см. matchDevice)
+func (s *ConnectionService) findDevice(endpointURL, model, manufacturer string, match *entities.DeviceSelector) (*entities.Device, error) {
+	devices, err := fetchDevices(endpointURL)
+	if err != nil {
+		return nil, err
+	}
+	return matchDevice(devices, endpointURL, model, manufacturer, match)
+}
+
+// fetchDevices запрашивает и разбирает /probe агента
+func fetchDevices(endpointURL string) ([]entities.Device, error) {
+	probeURL := strings.TrimSuffix(endpointURL, "/") + "/probe"
+
+	xmlData, err := FetchXML(probeURL)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить /probe с %s: %w", probeURL, err)
+	}
+
+	var devices entities.MTConnectDevices
+	if err := xml.Unmarshal(xmlData, &devices); err != nil {
+		return nil, entities.NewError(entities.ErrorCodeProbeInvalid, "не удалось распарсить /probe XML с %s: %w", probeURL, err)
+	}
+
+	if len(devices.Devices) == 0 {
+		return nil, entities.NewError(entities.ErrorCodeProbeInvalid, "устройства не найдены в /probe ответе от %s", probeURL)
+	}
+	return devices.Devices, nil
+}
+
+// matchDevice выбирает среди устройств /probe единственное подходящее. Если задан селектор, устройство
+// должно совпасть со всеми его условиями. Иначе ищется точное совпадение атрибута model, а при его
+// отсутствии - вхождение модели в текст Description. Если указан производитель, он должен совпадать
+// с указанным в /probe. Несколько подходящих устройств - ошибка DEVICE_AMBIGUOUS со списком кандидатов.
+func matchDevice(devices []entities.Device, endpointURL, model, manufacturer string, match *entities.DeviceSelector) (*entities.Device, error) {
+	var candidates []*entities.Device
+	criteria := fmt.Sprintf("моделью '%s'", model)
+	if match != nil {
+		if err := match.Validate(); err != nil {
+			return nil, err
+		}
+		criteria = fmt.Sprintf("селектором {%s}", match)
+		var pattern *regexp.Regexp
+		if match.Regex != "" {
+			pattern = regexp.MustCompile(match.Regex)
+		}
+		for i := range devices {
+			if matchSelector(&devices[i], match, pattern) {
+				candidates = append(candidates, &devices[i])
+			}
+		}
+	} else {
+		for i := range devices {
+			if devices[i].Description != nil && strings.EqualFold(devices[i].Description.Model, model) {
+				candidates = append(candidates, &devices[i])
+			}
+		}
+		if len(candidates) == 0 {
+			for i := range devices {
+				if devices[i].Description != nil && strings.Contains(normalizeText(devices[i].Description.Value), model) {
+					candidates = append(candidates, &devices[i])
+				}
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, entities.NewError(entities.ErrorCodeModelNotFound, "устройство с %s не найдено на эндпоинте %s", criteria, endpointURL)
+	}
+
+	if manufacturer != "" {
+		found := candidates
+		candidates = nil
+		for _, device := range found {
+			if device.Description != nil && strings.EqualFold(device.Description.Manufacturer, manufacturer) {
+				candidates = append(candidates, device)
+			}
+		}
+		if len(candidates) == 0 {
+			return nil, entities.NewError(entities.ErrorCodeModelNotFound, "производитель '%s' не совпадает с указанным в /probe для найденных устройств: %s", manufacturer, describeCandidates(found))
+		}
+	}
+
+	if len(candidates) > 1 {
+		ambiguity := &entities.DeviceAmbiguityError{
+			DomainError: entities.NewError(entities.ErrorCodeDeviceAmbiguous, "на эндпоинте %s найдено несколько устройств с %s: %s; уточните выбор селектором Match", endpointURL, criteria, describeCandidates(candidates)),
+		}
+		for _, device := range candidates {
+			ambiguity.Candidates = append(ambiguity.Candidates, deviceCandidate(device))
+		}
+		return nil, ambiguity
+	}
+	return candidates[0], nil
+}
+
+// matchSelector проверяет устройство на соответствие всем заданным условиям селектора
+func matchSelector(device *entities.Device, match *entities.DeviceSelector, pattern *regexp.Regexp) bool {
+	var description entities.Description
+	if device.Description != nil {
+		description = *device.Description
+	}
+	if match.UUID != "" && !strings.EqualFold(device.UUID, match.UUID) {
+		return false
+	}
+	if match.Name != "" && !strings.EqualFold(device.Name, match.Name) {
+		return false
+	}
+	if match.SerialNumber != "" && !strings.EqualFold(description.SerialNumber, match.SerialNumber) {
+		return false
+	}
+	if match.Model != "" && !strings.EqualFold(description.Model, match.Model) {
+		return false
+	}
+	if pattern != nil && !pattern.MatchString(normalizeText(description.Value)) {
+		return false
+	}
+	return true
+}
+
+// deviceCandidate возвращает идентифицирующие атрибуты устройства для ошибки неоднозначного выбора
+func deviceCandidate(device *entities.Device) entities.DeviceCandidate {
+	candidate := entities.DeviceCandidate{Name: device.Name, UUID: device.UUID}
+	if device.Description != nil {
+		candidate.Manufacturer = device.Description.Manufacturer
+		candidate.Model = device.Description.Model
+		candidate.SerialNumber = device.Description.SerialNumber
+	}
+	return candidate
+}
+
+// describeCandidates перечисляет устройства для текста ошибки: имя, UUID и серийный номер
+func describeCandidates(devices []*entities.Device) string {
+	parts := make([]string, 0, len(devices))
+	for _, device := range devices {
+		candidate := deviceCandidate(device)
+		parts = append(parts, fmt.Sprintf("'%s' (uuid: %s, model: %s, serialNumber: %s)", candidate.Name, candidate.UUID, candidate.Model, candidate.SerialNumber))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // ... Остальные функции (GetConnection, GetAllConnections, DeleteConnection, CheckConnection) остаются без изменений ...
 func (s *ConnectionService) GetConnection(sessionID string) (*entities.ConnectionInfo, bool) {
 	s.mu.RLock()
@@ -135,13 +555,15 @@ func (s *ConnectionService) GetAllConnections() []*entities.ConnectionInfo {
 func (s *ConnectionService) DeleteConnection(sessionID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if _, exists := s.pool[sessionID]; !exists {
-		return fmt.Errorf("сессия '%s' не найдена", sessionID)
+	conn, exists := s.pool[sessionID]
+	if !exists {
+		return entities.NewError(entities.ErrorCodeNotFound, "сессия '%s' не найдена", sessionID)
 	}
 
 	_ = s.pollingSvc.StopPollingForMachine(sessionID)
 
 	delete(s.pool, sessionID)
+	s.pollingSvc.PublishLifecycleEvent(conn, entities.LifecycleConnectionDeleted, "", nil)
 	return nil
 }
 
@@ -151,7 +573,7 @@ func (s *ConnectionService) CheckConnection(sessionID string) (*entities.Connect
 
 	conn, exists := s.pool[sessionID]
 	if !exists {
-		return nil, fmt.Errorf("сессия '%s' не найдена", sessionID)
+		return nil, entities.NewError(entities.ErrorCodeNotFound, "сессия '%s' не найдена", sessionID)
 	}
 
 	err := s.pollingSvc.CheckMachineConnection(conn.Config.EndpointURL)
@@ -2,11 +2,13 @@ package services
 
 import (
 	"MTConnect/internal/domain/entities"
+	"encoding/xml"
 	"fmt"
 	"math"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // formatAccumulatedTime преобразует строку с секундами в формат "ЧЧ:ММ:СС".
@@ -73,6 +75,7 @@ func MapToMachineData(streams *entities.MTConnectStreams, metadata map[string]en
 	machineDataMap := make(map[string]*entities.MachineData)
 	axisInfoMap := make(map[string]map[string]*entities.AxisInfo)
 	spindleInfoMap := make(map[string]map[string]*entities.SpindleInfo)
+	conditionMap := make(map[string]map[string]*conditionAccumulator)
 
 	for _, deviceStream := range streams.Streams {
 		machineID := deviceStream.Name
@@ -114,7 +117,9 @@ func MapToMachineData(streams *entities.MTConnectStreams, metadata map[string]en
 			}
 		}
 		machine := machineDataMap[machineID]
-		conditionsProcessedThisCycle := false
+		if _, ok := conditionMap[machineID]; !ok {
+			conditionMap[machineID] = make(map[string]*conditionAccumulator)
+		}
 
 		for _, compStream := range deviceStream.ComponentStreams {
 			if compStream.Samples != nil {
@@ -133,46 +138,9 @@ func MapToMachineData(streams *entities.MTConnectStreams, metadata map[string]en
 					}
 				}
 			}
-			if compStream.Condition != nil && len(compStream.Condition.Items) > 0 {
-				if !conditionsProcessedThisCycle {
-					machine.AlarmStatus = "NORMAL"
-					machine.WarningStatus = "NORMAL"
-					machine.Alarms = make([]map[string]interface{}, 0)
-					conditionsProcessedThisCycle = true
-				}
+			if compStream.Condition != nil {
 				for _, condition := range compStream.Condition.Items {
-					status := strings.ToUpper(condition.XMLName.Local)
-					if status == "FAULT" || status == "WARNING" {
-						alarm := make(map[string]interface{})
-						alarm["level"] = status
-						if meta, ok := metadata[strings.ToLower(condition.DataItemId)]; ok {
-							alarm["componentName"], alarm["componentId"] = meta.ComponentName, meta.ComponentId
-						} else {
-							alarm["componentName"], alarm["componentId"] = compStream.Name, compStream.ComponentId
-						}
-						if condition.Type != "" {
-							alarm["type"] = condition.Type
-						}
-						if condition.NativeCode != "" {
-							alarm["nativeCode"] = condition.NativeCode
-						}
-						if condition.Value != "" {
-							alarm["message"] = strings.TrimSpace(condition.Value)
-						}
-						if condition.DataItemId != "" {
-							alarm["dataItemId"] = condition.DataItemId
-						}
-						if condition.Timestamp != "" {
-							alarm["timestamp"] = condition.Timestamp
-						}
-						machine.Alarms = append(machine.Alarms, alarm)
-					}
-					if status == "FAULT" {
-						machine.AlarmStatus = "FAULT"
-					}
-					if status == "WARNING" {
-						machine.WarningStatus = "WARNING"
-					}
+					processConditionItem(conditionMap[machineID], compStream, condition, metadata)
 				}
 			}
 		}
@@ -200,7 +168,7 @@ func MapToMachineData(streams *entities.MTConnectStreams, metadata map[string]en
 				data.SpindleInfos = append(data.SpindleInfos, *machineSpindles[key])
 			}
 		}
-		data.HasAlarms = (data.AlarmStatus == "FAULT" || data.WarningStatus == "WARNING")
+		applyConditionStates(data, conditionMap[machineID])
 		if data.EditStatus == "UNAVAILABLE" && data.ProgramMode != "UNAVAILABLE" {
 			data.EditStatus = "NOT_READY"
 			if data.ProgramMode == "EDIT" {
@@ -218,6 +186,39 @@ func MapToMachineData(streams *entities.MTConnectStreams, metadata map[string]en
 	return machineDataSlice
 }
 
+// CountDataItems подсчитывает элементы данных станка в ответе: сопоставленные и отброшенные.
+// Samples и Events без метаданных /probe в MachineData не попадают; Condition учитываются всегда.
+func CountDataItems(streams *entities.MTConnectStreams, machineID string, metadata map[string]entities.DataItemMetadata) (mapped, dropped int) {
+	count := func(dataItemId string) {
+		if _, ok := metadata[strings.ToLower(dataItemId)]; ok {
+			mapped++
+		} else {
+			dropped++
+		}
+	}
+	for _, deviceStream := range streams.Streams {
+		if deviceStream.Name != machineID && (deviceStream.Name != "" || deviceStream.UUID != machineID) {
+			continue
+		}
+		for _, compStream := range deviceStream.ComponentStreams {
+			if compStream.Samples != nil {
+				for _, sample := range compStream.Samples.Items {
+					count(sample.DataItemId)
+				}
+			}
+			if compStream.Events != nil {
+				for _, event := range compStream.Events.Items {
+					count(event.DataItemId)
+				}
+			}
+			if compStream.Condition != nil {
+				mapped += len(compStream.Condition.Items)
+			}
+		}
+	}
+	return mapped, dropped
+}
+
 // processDataItem - логика сопоставления для всех остальных данных
 func processDataItem(machine *entities.MachineData, dataItemId, value, timestamp string, metadata map[string]entities.DataItemMetadata) {
 	meta, ok := metadata[strings.ToLower(dataItemId)]
@@ -318,3 +319,293 @@ func processDataItem(machine *entities.MachineData, dataItemId, value, timestamp
 		machine.CurrentProgram.LineLabel = value
 	}
 }
+
+// conditionAccumulator собирает состояние одного Condition DataItem'а в пределах снимка.
+// Активации хранятся по ключу nativeCode, поэтому один DataItem может содержать несколько активных кодов.
+type conditionAccumulator struct {
+	state       entities.ConditionState
+	unavailable bool
+	activations map[string]entities.ConditionActivation
+}
+
+// processConditionItem применяет одно наблюдение Condition к состоянию соответствующего DataItem'а
+func processConditionItem(conditions map[string]*conditionAccumulator, compStream entities.ComponentStream, condition entities.ConditionValue, metadata map[string]entities.DataItemMetadata) {
+	key := strings.ToLower(condition.DataItemId)
+	acc, ok := conditions[key]
+	if !ok {
+		acc = &conditionAccumulator{
+			state: entities.ConditionState{
+				DataItemId:    condition.DataItemId,
+				ComponentId:   compStream.ComponentId,
+				ComponentName: compStream.Name,
+			},
+			activations: make(map[string]entities.ConditionActivation),
+		}
+		if meta, ok := metadata[key]; ok {
+			acc.state.ComponentId, acc.state.ComponentName = meta.ComponentId, meta.ComponentName
+			acc.state.Type = meta.Type
+		}
+		conditions[key] = acc
+	}
+	if condition.Type != "" {
+		acc.state.Type = condition.Type
+	}
+	if acc.state.Timestamp < condition.Timestamp {
+		acc.state.Timestamp = condition.Timestamp
+	}
+
+	level := strings.ToUpper(condition.XMLName.Local)
+	switch level {
+	case entities.ConditionUnavailable:
+		// Источник условий недоступен: все ранее известные активации теряют смысл
+		acc.unavailable = true
+		acc.activations = make(map[string]entities.ConditionActivation)
+	case entities.ConditionNormal:
+		acc.unavailable = false
+		if condition.NativeCode == "" {
+			acc.activations = make(map[string]entities.ConditionActivation)
+		} else {
+			delete(acc.activations, condition.NativeCode)
+		}
+	case entities.ConditionWarning, entities.ConditionFault:
+		acc.unavailable = false
+		acc.activations[condition.NativeCode] = entities.ConditionActivation{
+			NativeCode:     condition.NativeCode,
+			NativeSeverity: condition.NativeSeverity,
+			Qualifier:      condition.Qualifier,
+			Level:          level,
+			Message:        strings.TrimSpace(condition.Value),
+			Timestamp:      condition.Timestamp,
+		}
+	}
+}
+
+// build формирует итоговое состояние DataItem'а с упорядоченным списком активаций
+func (acc *conditionAccumulator) build() entities.ConditionState {
+	state := acc.state
+	state.Activations = make([]entities.ConditionActivation, 0, len(acc.activations))
+	codes := make([]string, 0, len(acc.activations))
+	for code := range acc.activations {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	state.State = entities.ConditionNormal
+	if acc.unavailable {
+		state.State = entities.ConditionUnavailable
+	}
+	for _, code := range codes {
+		activation := acc.activations[code]
+		state.Activations = append(state.Activations, activation)
+		if activation.Level == entities.ConditionFault || state.State == entities.ConditionNormal {
+			state.State = activation.Level
+		}
+	}
+	return state
+}
+
+// applyConditionStates заполняет Conditions, Alarms и агрегированные статусы станка.
+// Если все источники условий недоступны, статусы остаются UNAVAILABLE, чтобы потребитель
+// мог отличить "нет аварий" от "источник аварий не отвечает".
+func applyConditionStates(machine *entities.MachineData, conditions map[string]*conditionAccumulator) {
+	if len(conditions) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(conditions))
+	for k := range conditions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	anyAvailable, hasFault, hasWarning := false, false, false
+	machine.Alarms = make([]map[string]interface{}, 0)
+	for _, key := range keys {
+		state := conditions[key].build()
+		machine.Conditions = append(machine.Conditions, state)
+		if state.State != entities.ConditionUnavailable {
+			anyAvailable = true
+		}
+		for _, activation := range state.Activations {
+			hasFault = hasFault || activation.Level == entities.ConditionFault
+			hasWarning = hasWarning || activation.Level == entities.ConditionWarning
+			machine.Alarms = append(machine.Alarms, conditionActivationToAlarm(state, activation))
+		}
+	}
+
+	if !anyAvailable {
+		machine.AlarmStatus = entities.ConditionUnavailable
+		machine.WarningStatus = entities.ConditionUnavailable
+		machine.HasAlarms = entities.ConditionUnavailable
+		return
+	}
+	machine.AlarmStatus = entities.ConditionNormal
+	if hasFault {
+		machine.AlarmStatus = entities.ConditionFault
+	}
+	machine.WarningStatus = entities.ConditionNormal
+	if hasWarning {
+		machine.WarningStatus = entities.ConditionWarning
+	}
+	machine.HasAlarms = hasFault || hasWarning
+}
+
+// conditionActivationToAlarm формирует запись Alarms в прежнем плоском формате
+func conditionActivationToAlarm(state entities.ConditionState, activation entities.ConditionActivation) map[string]interface{} {
+	alarm := make(map[string]interface{})
+	alarm["level"] = activation.Level
+	alarm["componentName"], alarm["componentId"] = state.ComponentName, state.ComponentId
+	if state.Type != "" {
+		alarm["type"] = state.Type
+	}
+	if activation.NativeCode != "" {
+		alarm["nativeCode"] = activation.NativeCode
+	}
+	if activation.NativeSeverity != "" {
+		alarm["nativeSeverity"] = activation.NativeSeverity
+	}
+	if activation.Qualifier != "" {
+		alarm["qualifier"] = activation.Qualifier
+	}
+	if activation.Message != "" {
+		alarm["message"] = activation.Message
+	}
+	if state.DataItemId != "" {
+		alarm["dataItemId"] = state.DataItemId
+	}
+	if activation.Timestamp != "" {
+		alarm["timestamp"] = activation.Timestamp
+	}
+	return alarm
+}
+
+// StreamPositions возвращает для каждого станка экземпляр агента и диапазон последовательностей
+// наблюдений, вошедших в ответ. Если агент не передает sequence, используется диапазон из заголовка.
+func StreamPositions(streams *entities.MTConnectStreams) map[string]entities.StreamPosition {
+	positions := make(map[string]entities.StreamPosition)
+	for _, deviceStream := range streams.Streams {
+		machineID := deviceStream.Name
+		if machineID == "" {
+			machineID = deviceStream.UUID
+		}
+		position := positions[machineID]
+		position.AgentInstanceID = streams.Header.InstanceId
+		observe := func(sequence uint64) {
+			if sequence == 0 {
+				return
+			}
+			if position.FirstSequence == 0 || sequence < position.FirstSequence {
+				position.FirstSequence = sequence
+			}
+			if sequence > position.LastSequence {
+				position.LastSequence = sequence
+			}
+		}
+		for _, compStream := range deviceStream.ComponentStreams {
+			if compStream.Samples != nil {
+				for _, sample := range compStream.Samples.Items {
+					observe(sample.Sequence)
+				}
+			}
+			if compStream.Events != nil {
+				for _, event := range compStream.Events.Items {
+					observe(event.Sequence)
+				}
+			}
+			if compStream.Condition != nil {
+				for _, condition := range compStream.Condition.Items {
+					observe(condition.Sequence)
+				}
+			}
+		}
+		if position.LastSequence == 0 {
+			position.FirstSequence, position.LastSequence = streams.Header.FirstSequence, streams.Header.LastSequence
+		}
+		positions[machineID] = position
+	}
+	return positions
+}
+
+// ExtractObservations возвращает исходные наблюдения ответа по станкам в порядке sequence.
+// Тип, подтип и путь компонента берутся из метаданных /probe, а при их отсутствии - из самого ответа.
+func ExtractObservations(streams *entities.MTConnectStreams, metadata map[string]entities.DataItemMetadata) map[string][]entities.Observation {
+	observations := make(map[string][]entities.Observation)
+	for _, deviceStream := range streams.Streams {
+		machineID := deviceStream.Name
+		if machineID == "" {
+			machineID = deviceStream.UUID
+		}
+		for _, compStream := range deviceStream.ComponentStreams {
+			newObservation := func(category string, element xml.Name, dataItemId string, sequence uint64, timestamp, name, subType, value string) entities.Observation {
+				meta := metadata[strings.ToLower(dataItemId)]
+				observation := entities.Observation{
+					MachineId:     machineID,
+					DeviceUUID:    deviceStream.UUID,
+					Sequence:      sequence,
+					Timestamp:     timestamp,
+					DataItemId:    dataItemId,
+					Name:          name,
+					Category:      category,
+					Type:          meta.Type,
+					SubType:       subType,
+					ComponentPath: meta.ComponentPath,
+					Value:         strings.TrimSpace(value),
+				}
+				if observation.Type == "" {
+					observation.Type = upperSnakeCase(element.Local)
+				}
+				if observation.SubType == "" {
+					observation.SubType = meta.SubType
+				}
+				if observation.ComponentPath == "" {
+					observation.ComponentPath = fmt.Sprintf("%s/%s[%s]", machineID, compStream.Component, compStream.Name)
+				}
+				return observation
+			}
+
+			if compStream.Samples != nil {
+				for _, sample := range compStream.Samples.Items {
+					observations[machineID] = append(observations[machineID],
+						newObservation("SAMPLE", sample.XMLName, sample.DataItemId, sample.Sequence, sample.Timestamp, sample.Name, sample.SubType, sample.Value))
+				}
+			}
+			if compStream.Events != nil {
+				for _, event := range compStream.Events.Items {
+					observations[machineID] = append(observations[machineID],
+						newObservation("EVENT", event.XMLName, event.DataItemId, event.Sequence, event.Timestamp, event.Name, "", event.Value))
+				}
+			}
+			if compStream.Condition != nil {
+				for _, condition := range compStream.Condition.Items {
+					// Для условий элемент ответа - уровень, а тип передается атрибутом
+					observation := newObservation("CONDITION", condition.XMLName, condition.DataItemId, condition.Sequence, condition.Timestamp, condition.Name, "", condition.XMLName.Local)
+					if condition.Type != "" {
+						observation.Type = condition.Type
+					}
+					observation.NativeCode = condition.NativeCode
+					observation.NativeSeverity = condition.NativeSeverity
+					observation.Qualifier = condition.Qualifier
+					observation.Message = strings.TrimSpace(condition.Value)
+					observations[machineID] = append(observations[machineID], observation)
+				}
+			}
+		}
+	}
+	for machineID := range observations {
+		sort.SliceStable(observations[machineID], func(i, j int) bool {
+			return observations[machineID][i].Sequence < observations[machineID][j].Sequence
+		})
+	}
+	return observations
+}
+
+// upperSnakeCase переводит имя элемента ответа (PathFeedrate) в обозначение типа DataItem (PATH_FEEDRATE)
+func upperSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
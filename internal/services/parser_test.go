@@ -0,0 +1,132 @@
+package services
+
+import (
+	"MTConnect/internal/domain/entities"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+// conditionStreams оборачивает элементы Condition в ответ /current одного станка
+func conditionStreams(t *testing.T, conditions string) *entities.MTConnectStreams {
+	t.Helper()
+	document := `<?xml version="1.0"?>
+<MTConnectStreams>
+  <Header instanceId="1" firstSequence="1" lastSequence="100" nextSequence="101"/>
+  <Streams>
+    <DeviceStream name="Mazak" uuid="mazak-uuid">
+      <ComponentStream component="Controller" name="controller" componentId="cont">
+        <Condition>` + conditions + `</Condition>
+      </ComponentStream>
+    </DeviceStream>
+  </Streams>
+</MTConnectStreams>`
+	var streams entities.MTConnectStreams
+	if err := xml.Unmarshal([]byte(document), &streams); err != nil {
+		t.Fatal(err)
+	}
+	return &streams
+}
+
+func TestMapToMachineDataConditions(t *testing.T) {
+	tests := []struct {
+		name          string
+		conditions    string
+		wantState     map[string]string
+		wantCodes     map[string]string
+		wantAlarm     string
+		wantWarning   string
+		wantHasAlarms interface{}
+	}{
+		{
+			name: "две одновременные аварии одного DataItem",
+			conditions: `
+				<Fault dataItemId="sys" timestamp="2024-01-01T00:00:01Z" nativeCode="E1" type="SYSTEM">Overheat</Fault>
+				<Fault dataItemId="sys" timestamp="2024-01-01T00:00:02Z" nativeCode="E2" type="SYSTEM">Low pressure</Fault>`,
+			wantState:     map[string]string{"sys": entities.ConditionFault},
+			wantCodes:     map[string]string{"sys": "E1,E2"},
+			wantAlarm:     entities.ConditionFault,
+			wantWarning:   entities.ConditionNormal,
+			wantHasAlarms: true,
+		},
+		{
+			name: "NORMAL с nativeCode снимает только этот код",
+			conditions: `
+				<Fault dataItemId="sys" timestamp="2024-01-01T00:00:01Z" nativeCode="E1" type="SYSTEM">Overheat</Fault>
+				<Warning dataItemId="sys" timestamp="2024-01-01T00:00:02Z" nativeCode="E2" type="SYSTEM">Low oil</Warning>
+				<Normal dataItemId="sys" timestamp="2024-01-01T00:00:03Z" nativeCode="E1" type="SYSTEM"/>`,
+			wantState:     map[string]string{"sys": entities.ConditionWarning},
+			wantCodes:     map[string]string{"sys": "E2"},
+			wantAlarm:     entities.ConditionNormal,
+			wantWarning:   entities.ConditionWarning,
+			wantHasAlarms: true,
+		},
+		{
+			name: "NORMAL без nativeCode снимает все коды",
+			conditions: `
+				<Fault dataItemId="sys" timestamp="2024-01-01T00:00:01Z" nativeCode="E1" type="SYSTEM">Overheat</Fault>
+				<Warning dataItemId="sys" timestamp="2024-01-01T00:00:02Z" nativeCode="E2" type="SYSTEM">Low oil</Warning>
+				<Normal dataItemId="sys" timestamp="2024-01-01T00:00:03Z" type="SYSTEM"/>`,
+			wantState:     map[string]string{"sys": entities.ConditionNormal},
+			wantCodes:     map[string]string{"sys": ""},
+			wantAlarm:     entities.ConditionNormal,
+			wantWarning:   entities.ConditionNormal,
+			wantHasAlarms: false,
+		},
+		{
+			name: "UNAVAILABLE сбрасывает активации",
+			conditions: `
+				<Fault dataItemId="sys" timestamp="2024-01-01T00:00:01Z" nativeCode="E1" type="SYSTEM">Overheat</Fault>
+				<Unavailable dataItemId="sys" timestamp="2024-01-01T00:00:02Z" type="SYSTEM"/>`,
+			wantState:     map[string]string{"sys": entities.ConditionUnavailable},
+			wantCodes:     map[string]string{"sys": ""},
+			wantAlarm:     entities.ConditionUnavailable,
+			wantWarning:   entities.ConditionUnavailable,
+			wantHasAlarms: entities.ConditionUnavailable,
+		},
+		{
+			name: "недоступный источник не скрывает аварию другого DataItem",
+			conditions: `
+				<Unavailable dataItemId="sys" timestamp="2024-01-01T00:00:01Z" type="SYSTEM"/>
+				<Fault dataItemId="hw" timestamp="2024-01-01T00:00:02Z" nativeCode="H1" type="HARDWARE">Drive</Fault>`,
+			wantState:     map[string]string{"sys": entities.ConditionUnavailable, "hw": entities.ConditionFault},
+			wantCodes:     map[string]string{"sys": "", "hw": "H1"},
+			wantAlarm:     entities.ConditionFault,
+			wantWarning:   entities.ConditionNormal,
+			wantHasAlarms: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			machines := MapToMachineData(conditionStreams(t, test.conditions), nil, nil, nil)
+			if len(machines) != 1 {
+				t.Fatalf("станков: %d, ожидался 1", len(machines))
+			}
+			machine := machines[0]
+			if len(machine.Conditions) != len(test.wantState) {
+				t.Fatalf("условий: %d, ожидалось %d", len(machine.Conditions), len(test.wantState))
+			}
+			alarms := 0
+			for _, condition := range machine.Conditions {
+				if condition.State != test.wantState[condition.DataItemId] {
+					t.Errorf("состояние %s = %s, ожидалось %s", condition.DataItemId, condition.State, test.wantState[condition.DataItemId])
+				}
+				codes := make([]string, 0, len(condition.Activations))
+				for _, activation := range condition.Activations {
+					codes = append(codes, activation.NativeCode)
+				}
+				if got := strings.Join(codes, ","); got != test.wantCodes[condition.DataItemId] {
+					t.Errorf("активные коды %s = [%s], ожидалось [%s]", condition.DataItemId, got, test.wantCodes[condition.DataItemId])
+				}
+				alarms += len(condition.Activations)
+			}
+			if len(machine.Alarms) != alarms {
+				t.Errorf("записей Alarms: %d, ожидалось %d", len(machine.Alarms), alarms)
+			}
+			if machine.AlarmStatus != test.wantAlarm || machine.WarningStatus != test.wantWarning || machine.HasAlarms != test.wantHasAlarms {
+				t.Errorf("AlarmStatus = %v, WarningStatus = %v, HasAlarms = %v, ожидалось %v, %v, %v",
+					machine.AlarmStatus, machine.WarningStatus, machine.HasAlarms, test.wantAlarm, test.wantWarning, test.wantHasAlarms)
+			}
+		})
+	}
+}
@@ -1,26 +1,68 @@
 package services
 
 import (
+	"MTConnect/internal/config"
 	"MTConnect/internal/domain/entities"
 	"MTConnect/internal/interfaces"
+	"MTConnect/internal/logging"
 	"context"
-	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
-	"log"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var pollLog = logging.Logger(logging.ComponentPoller)
+
 type activePoll struct {
+	conn   *entities.ConnectionInfo
 	ticker *time.Ticker
 	done   chan bool
+	// stopped закрывается при выходе горутины опроса
+	stopped   chan struct{}
+	interval  time.Duration
+	startedAt time.Time
+	// lastData - время последнего полученного снимка в наносекундах Unix, 0 до первого снимка
+	lastData atomic.Int64
+}
+
+// observationCursor - положение сессии в потоке исходных наблюдений агента
+type observationCursor struct {
+	instanceID string
+	// nextSequence - следующая непрочитанная последовательность (режим sample)
+	nextSequence uint64
+	// lastSeen - последнее опубликованное наблюдение каждого DataItem (режим current);
+	// для условий - каждой активации DataItem, см. observationIdentity
+	lastSeen map[string]string
+}
+
+// maxSamplePages ограничивает число запросов /sample за один цикл опроса,
+// чтобы отставший курсор не задерживал опрос надолго
+const maxSamplePages = 10
+
+// agentState - последнее известное состояние агента сессии для событий жизненного цикла
+type agentState struct {
+	reachable  bool
+	instanceID string
 }
 
 type PollingService struct {
 	repo                 interfaces.DataStoreRepository
 	producer             interfaces.DataProducer
+	alarmSvc             interfaces.AlarmService
+	hub                  interfaces.StreamHub
+	metrics              interfaces.Metrics
+	publishCfg           config.PublishConfig
+	observationsCfg      config.ObservationsConfig
+	changeDetector       *ChangeDetector
 	activePolls          map[string]*activePoll
 	pollsMutex           sync.Mutex
 	deviceMetadataStore  map[string]entities.DataItemMetadata
@@ -29,20 +71,35 @@ type PollingService struct {
 	metadataMutex        sync.RWMutex
 	axisLinksMutex       sync.RWMutex
 	spindleLinksMutex    sync.RWMutex
+	agentStates          map[string]*agentState
+	agentStatesMutex     sync.Mutex
+	observationCursors   map[string]*observationCursor
+	observationsMutex    sync.Mutex
+	// errorLimiter подавляет повторы одной и той же ошибки опроса сессии
+	errorLimiter *logging.RepeatLimiter
 
 	// --- НОВЫЕ ПОЛЯ ДЛЯ ХРАНЕНИЯ СОСТОЯНИЯ ---
 	isPollingActive bool
 	pollingInterval time.Duration
 }
 
-func NewPollingService(repo interfaces.DataStoreRepository, producer interfaces.DataProducer) interfaces.PollingService {
+func NewPollingService(cfg *config.AppConfig, repo interfaces.DataStoreRepository, producer interfaces.DataProducer, alarmSvc interfaces.AlarmService, hub interfaces.StreamHub, metrics interfaces.Metrics) interfaces.PollingService {
 	ps := &PollingService{
 		repo:                 repo,
 		producer:             producer,
+		alarmSvc:             alarmSvc,
+		hub:                  hub,
+		metrics:              metrics,
+		publishCfg:           cfg.Publish,
+		observationsCfg:      cfg.Observations,
+		changeDetector:       NewChangeDetector(cfg.Publish),
 		activePolls:          make(map[string]*activePoll),
 		deviceMetadataStore:  make(map[string]entities.DataItemMetadata),
 		axisDataItemLinks:    make(map[string]entities.AxisDataItemLink),
 		spindleDataItemLinks: make(map[string]entities.SpindleDataItemLink),
+		agentStates:          make(map[string]*agentState),
+		observationCursors:   make(map[string]*observationCursor),
+		errorLimiter:         logging.NewRepeatLimiter(time.Duration(cfg.Logging.RepeatIntervalSec) * time.Second),
 		isPollingActive:      false, // Изначально опрос выключен
 	}
 	return ps
@@ -51,104 +108,217 @@ func NewPollingService(repo interfaces.DataStoreRepository, producer interfaces.
 // StartPollingForNewConnectionIfNeeded проверяет, активен ли опрос, и если да, запускает его для нового подключения.
 func (s *PollingService) StartPollingForNewConnectionIfNeeded(conn *entities.ConnectionInfo) error {
 	s.pollsMutex.Lock()
-	defer s.pollsMutex.Unlock()
-
-	if s.isPollingActive {
-		log.Printf("Глобальный опрос активен. Запускаем polling для новой сессии: %s", conn.SessionID)
-		// Используем уже сохраненный интервал
-		return s.startPollingForMachineUnsafe(conn, s.pollingInterval)
+	if !s.isPollingActive {
+		s.pollsMutex.Unlock()
+		return nil
 	}
+	pollLog.Info("глобальный опрос активен, опрос запускается для новой сессии", logging.Session(conn))
+	// Используем уже сохраненный интервал
+	poll, err := s.startPollingForMachineUnsafe(conn, s.pollingInterval)
+	s.pollsMutex.Unlock()
+	if err != nil {
+		return err
+	}
+	s.runPoll(poll)
 	return nil
 }
 
-// startPollingForMachineUnsafe - внутренняя версия без блокировки мьютекса
-func (s *PollingService) startPollingForMachineUnsafe(conn *entities.ConnectionInfo, interval time.Duration) error {
+// startPollingForMachineUnsafe регистрирует опрос сессии; вызывается под pollsMutex,
+// после освобождения которого опрос нужно запустить вызовом runPoll
+func (s *PollingService) startPollingForMachineUnsafe(conn *entities.ConnectionInfo, interval time.Duration) (*activePoll, error) {
 	if _, exists := s.activePolls[conn.SessionID]; exists {
-		return fmt.Errorf("опрос для сессии '%s' уже запущен", conn.SessionID)
+		return nil, entities.NewError(entities.ErrorCodeAlreadyExists, "опрос для сессии '%s' уже запущен", conn.SessionID)
 	}
 
 	ticker := time.NewTicker(interval)
 	done := make(chan bool)
 
-	s.activePolls[conn.SessionID] = &activePoll{
-		ticker: ticker,
-		done:   done,
+	poll := &activePoll{
+		conn:      conn,
+		ticker:    ticker,
+		done:      done,
+		stopped:   make(chan struct{}),
+		interval:  interval,
+		startedAt: time.Now(),
 	}
+	s.activePolls[conn.SessionID] = poll
+	return poll, nil
+}
+
+// runPoll публикует событие запуска и запускает горутину опроса; вызывается без pollsMutex.
+// Событие публикуется до запуска горутины, поэтому оно всегда предшествует данным и событию остановки.
+func (s *PollingService) runPoll(poll *activePoll) {
+	conn := poll.conn
+	s.PublishLifecycleEvent(conn, entities.LifecyclePollingStarted, "", map[string]string{"intervalMs": fmt.Sprint(poll.interval.Milliseconds())})
 
 	go func() {
-		log.Printf("Запуск опроса для сессии '%s' (станок: %s) с интервалом %v", conn.SessionID, conn.MachineID, interval)
+		defer close(poll.stopped)
+		pollLog.Info("опрос запущен", logging.Session(conn), "intervalMs", poll.interval.Milliseconds())
 		currentURL := strings.TrimSuffix(conn.Config.EndpointURL, "/") + "/current"
 		for {
 			select {
-			case <-done:
-				log.Printf("Остановлен опрос для сессии '%s'", conn.SessionID)
+			case <-poll.done:
+				pollLog.Info("опрос остановлен", logging.Session(conn))
 				return
-			case <-ticker.C:
-				s.processSingleEndpoint(currentURL, conn.MachineID)
+			case <-poll.ticker.C:
+				if s.processSingleEndpoint(currentURL, conn) {
+					poll.lastData.Store(time.Now().UnixNano())
+				}
 			}
 		}
 	}()
-	return nil
 }
 
 func (s *PollingService) StartPollingForMachine(conn *entities.ConnectionInfo, interval time.Duration) error {
 	s.pollsMutex.Lock()
-	defer s.pollsMutex.Unlock()
-	return s.startPollingForMachineUnsafe(conn, interval)
+	poll, err := s.startPollingForMachineUnsafe(conn, interval)
+	s.pollsMutex.Unlock()
+	if err != nil {
+		return err
+	}
+	s.runPoll(poll)
+	return nil
 }
 
 func (s *PollingService) StopPollingForMachine(sessionID string) error {
 	s.pollsMutex.Lock()
-	defer s.pollsMutex.Unlock()
 	poll, exists := s.activePolls[sessionID]
-	if !exists {
-		return nil
+	if exists {
+		s.detachPollUnsafe(sessionID, poll)
 	}
+	s.pollsMutex.Unlock()
+	if exists {
+		s.finishPoll(sessionID, poll)
+	}
+	return nil
+}
+
+// detachPollUnsafe исключает опрос из активных и сигнализирует горутине остановиться, не дожидаясь ее;
+// вызывается под pollsMutex, после освобождения которого нужно вызвать finishPoll
+func (s *PollingService) detachPollUnsafe(sessionID string, poll *activePoll) {
 	poll.ticker.Stop()
-	poll.done <- true
 	close(poll.done)
 	delete(s.activePolls, sessionID)
-	return nil
 }
 
-func (s *PollingService) StartAllPolling(connections []*entities.ConnectionInfo, interval time.Duration) error {
+// finishPoll дожидается завершения текущего цикла опроса, очищает состояние сессии
+// и публикует событие остановки; вызывается без pollsMutex, чтобы медленный агент
+// или синки не блокировали управление опросом других сессий
+func (s *PollingService) finishPoll(sessionID string, poll *activePoll) {
+	<-poll.stopped
+
+	s.agentStatesMutex.Lock()
+	delete(s.agentStates, sessionID)
+	s.agentStatesMutex.Unlock()
+	s.observationsMutex.Lock()
+	delete(s.observationCursors, sessionID)
+	s.observationsMutex.Unlock()
+	s.metrics.ForgetSession(sessionID)
+	s.errorLimiter.Reset(sessionID)
+	s.PublishLifecycleEvent(poll.conn, entities.LifecyclePollingStopped, "", nil)
+}
+
+func (s *PollingService) ActivePolls() int {
 	s.pollsMutex.Lock()
 	defer s.pollsMutex.Unlock()
+	return len(s.activePolls)
+}
+
+// PollStates возвращает состояние циклов опроса для проверки готовности
+func (s *PollingService) PollStates() []entities.PollState {
+	s.pollsMutex.Lock()
+	states := make([]entities.PollState, 0, len(s.activePolls))
+	for sessionID, poll := range s.activePolls {
+		state := entities.PollState{
+			SessionID: sessionID,
+			MachineID: poll.conn.MachineID,
+			Interval:  poll.interval,
+			StartedAt: poll.startedAt,
+		}
+		if lastData := poll.lastData.Load(); lastData != 0 {
+			at := time.Unix(0, lastData)
+			state.LastData = &at
+		}
+		states = append(states, state)
+	}
+	s.pollsMutex.Unlock()
+
+	s.agentStatesMutex.Lock()
+	defer s.agentStatesMutex.Unlock()
+	for i := range states {
+		if agent, known := s.agentStates[states[i].SessionID]; known {
+			reachable := agent.reachable
+			states[i].Reachable = &reachable
+		}
+	}
+	return states
+}
 
-	log.Println("Запуск опроса для всех активных подключений...")
+func (s *PollingService) StartAllPolling(connections []*entities.ConnectionInfo, interval time.Duration) error {
+	s.pollsMutex.Lock()
+	pollLog.Info("запуск опроса всех исправных подключений", "intervalMs", interval.Milliseconds())
 	// Сохраняем состояние
 	s.isPollingActive = true
 	s.pollingInterval = interval
 
-	var errs []string
+	var errs []error
+	var started []*activePoll
 	for _, conn := range connections {
 		if conn.IsHealthy {
-			if err := s.startPollingForMachineUnsafe(conn, interval); err != nil {
-				errs = append(errs, err.Error())
+			poll, err := s.startPollingForMachineUnsafe(conn, interval)
+			if err != nil {
+				errs = append(errs, err)
+				continue
 			}
+			started = append(started, poll)
 		}
 	}
-	if len(errs) > 0 {
-		return fmt.Errorf("возникли ошибки при запуске опроса: %s", strings.Join(errs, "; "))
+	s.pollsMutex.Unlock()
+
+	for _, poll := range started {
+		s.runPoll(poll)
+	}
+	return joinStartErrors(errs)
+}
+
+// joinStartErrors объединяет ошибки запуска опроса отдельных сессий. Код ошибки сохраняется,
+// если он у всех ошибок одинаков; при разных кодах возвращается внутренняя ошибка.
+func joinStartErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	code := entities.ErrorCodeOf(errs[0])
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if entities.ErrorCodeOf(err) != code {
+			code = entities.ErrorCodeInternal
+		}
+		messages = append(messages, err.Error())
+	}
+	return &entities.DomainError{
+		Code:    code,
+		Message: "возникли ошибки при запуске опроса: " + strings.Join(messages, "; "),
+		Err:     errors.Join(errs...),
 	}
-	return nil
 }
 
 func (s *PollingService) StopAllPolling() {
 	s.pollsMutex.Lock()
-	defer s.pollsMutex.Unlock()
-
-	log.Println("Остановка всех процессов опроса...")
+	pollLog.Info("остановка опроса всех подключений", "activePolls", len(s.activePolls))
 	// Сбрасываем состояние
 	s.isPollingActive = false
 
+	stopped := make(map[string]*activePoll, len(s.activePolls))
 	for sessionID, poll := range s.activePolls {
-		poll.ticker.Stop()
-		poll.done <- true
-		close(poll.done)
-		delete(s.activePolls, sessionID)
+		s.detachPollUnsafe(sessionID, poll)
+		stopped[sessionID] = poll
+	}
+	s.pollsMutex.Unlock()
+
+	for sessionID, poll := range stopped {
+		s.finishPoll(sessionID, poll)
 	}
-	log.Println("Все процессы опроса остановлены.")
+	pollLog.Info("опрос всех подключений остановлен")
 }
 
 // ... Остальные функции (CheckMachineConnection, LoadMetadataForEndpoint, processSingleEndpoint, и т.д.) остаются без изменений ...
@@ -164,7 +334,7 @@ func (s *PollingService) CheckMachineConnection(endpointURL string) error {
 
 func (s *PollingService) LoadMetadataForEndpoint(endpointURL string) error {
 	if err := s.fetchAndParseProbe(endpointURL); err != nil {
-		log.Printf("ПРЕДУПРЕЖДЕНИЕ: %v. Некоторые данные могут быть не распознаны.", err)
+		pollLog.Warn("метаданные /probe не загружены, часть данных может быть не распознана", logging.KeyEndpoint, endpointURL, logging.Err(err))
 		return err
 	}
 	s.metadataMutex.RLock()
@@ -174,54 +344,347 @@ func (s *PollingService) LoadMetadataForEndpoint(endpointURL string) error {
 	s.spindleLinksMutex.RLock()
 	defer s.spindleLinksMutex.RUnlock()
 
-	log.Printf("Загружено %d уникальных DataItem'ов.", len(s.deviceMetadataStore))
-	log.Printf("Загружено %d ссылок на DataItem'ы осей.", len(s.axisDataItemLinks))
-	log.Printf("Загружено %d ссылок на DataItem'ы шпинделей.", len(s.spindleDataItemLinks))
+	pollLog.Info("метаданные /probe загружены", logging.KeyEndpoint, endpointURL,
+		"dataItems", len(s.deviceMetadataStore),
+		"axisLinks", len(s.axisDataItemLinks),
+		"spindleLinks", len(s.spindleDataItemLinks))
 	return nil
 }
 
-func (s *PollingService) processSingleEndpoint(endpointURL string, targetMachineID string) {
-	xmlData, err := FetchXML(endpointURL)
+// processSingleEndpoint выполняет один цикл опроса сессии и сообщает, получен ли снимок станка.
+// Цикл записывается спаном poll с дочерними спанами этапов; контекст спана передается в сообщения синков.
+func (s *PollingService) processSingleEndpoint(endpointURL string, conn *entities.ConnectionInfo) bool {
+	started := time.Now()
+	defer func() { s.metrics.ObservePoll(conn.SessionID, conn.MachineID, time.Since(started)) }()
+	ctx, span := tracer().Start(context.Background(), "poll", sessionAttributes(conn))
+	defer span.End()
+
+	xmlData, err := FetchXMLContext(ctx, endpointURL)
 	if err != nil {
-		log.Printf("ОШИБКА при получении XML с %s: %v\n", endpointURL, err)
-		return
+		kind := FetchErrorKind(err)
+		s.logPollError(ctx, conn, "ошибка получения ответа агента", kind, err, "url", endpointURL)
+		s.metrics.FetchError(conn.SessionID, kind)
+		recordSpanError(span, err)
+		s.trackAgentState(conn, err, "")
+		return false
 	}
 
 	var streams entities.MTConnectStreams
+	parseStarted := time.Now()
+	_, parseSpan := tracer().Start(ctx, "xml.Unmarshal", trace.WithAttributes(attribute.Int("mtconnect.response_bytes", len(xmlData))))
 	if err := xml.Unmarshal(xmlData, &streams); err != nil {
-		log.Printf("ОШИБКА при парсинге XML с %s: %v\n", endpointURL, err)
-		return
+		s.logPollError(ctx, conn, "ошибка разбора ответа агента", FetchErrorParse, err, "url", endpointURL)
+		s.metrics.FetchError(conn.SessionID, FetchErrorParse)
+		recordSpanError(parseSpan, err)
+		parseSpan.End()
+		recordSpanError(span, err)
+		s.trackAgentState(conn, err, "")
+		return false
+	}
+	parseSpan.End()
+	s.metrics.ObserveParse("unmarshal", time.Since(parseStarted))
+	span.SetAttributes(attribute.String("mtconnect.agent_instance_id", streams.Header.InstanceId))
+	s.trackAgentState(conn, nil, streams.Header.InstanceId)
+	if suppressed, failed := s.errorLimiter.Reset(conn.SessionID); failed {
+		pollLog.InfoContext(ctx, "опрос восстановлен", logging.Session(conn), "suppressed", suppressed)
 	}
 
 	s.metadataMutex.RLock()
 	s.axisLinksMutex.RLock()
 	s.spindleLinksMutex.RLock()
+	mapStarted := time.Now()
+	_, mapSpan := tracer().Start(ctx, "MapToMachineData")
 	machineDataSlice := MapToMachineData(&streams, s.deviceMetadataStore, s.axisDataItemLinks, s.spindleDataItemLinks)
+	s.metrics.ObserveParse("map", time.Since(mapStarted))
+	mapped, dropped := CountDataItems(&streams, conn.MachineID, s.deviceMetadataStore)
+	mapSpan.SetAttributes(attribute.Int("mtconnect.data_items.mapped", mapped), attribute.Int("mtconnect.data_items.dropped", dropped))
+	mapSpan.End()
 	s.spindleLinksMutex.RUnlock()
 	s.axisLinksMutex.RUnlock()
 	s.metadataMutex.RUnlock()
+	s.metrics.DataItems(conn.MachineID, mapped, dropped)
 
+	received := false
 	for _, machineData := range machineDataSlice {
-		if machineData.MachineId == targetMachineID {
+		if machineData.MachineId == conn.MachineID {
+			position := StreamPositions(&streams)[machineData.MachineId]
+			_, setSpan := tracer().Start(ctx, "repo.Set")
 			s.repo.Set(machineData.MachineId, machineData)
+			setSpan.End()
+			s.hub.Publish(entities.StreamEvent{Event: entities.StreamEventMachineData, SessionID: conn.SessionID, MachineID: conn.MachineID, Data: machineData})
+			s.publishMachineData(ctx, conn, position, machineData)
+			s.publishAlarmEvents(ctx, conn, position, machineData)
+			received = true
+			break
+		}
+	}
+	if s.observationsCfg.Enabled {
+		s.publishObservations(ctx, conn, &streams)
+	}
+	span.SetAttributes(attribute.Bool("mtconnect.data_received", received))
+	return received
+}
 
-			jsonData, err := json.Marshal(machineData)
-			if err != nil {
-				log.Printf("ОШИБКА: не удалось сериализовать MachineData для Kafka: %v", err)
-				continue
-			}
-			err = s.producer.Produce(context.Background(), []byte(machineData.MachineId), jsonData)
-			if err != nil {
-				log.Printf("ОШИБКА: не удалось отправить данные в Kafka для станка %s: %v", machineData.MachineId, err)
+// logPollError пишет ошибку опроса сессии. Повторы ошибки того же класса в течение
+// logging.repeat_interval_sec только подсчитываются и упоминаются в следующей записи.
+func (s *PollingService) logPollError(ctx context.Context, conn *entities.ConnectionInfo, msg, class string, err error, attrs ...any) {
+	allowed, suppressed := s.errorLimiter.Allow(conn.SessionID, class)
+	if !allowed {
+		return
+	}
+	args := append([]any{logging.Session(conn), logging.ErrorClass(class), logging.Err(err)}, attrs...)
+	if suppressed > 0 {
+		args = append(args, "suppressed", suppressed)
+	}
+	pollLog.ErrorContext(ctx, msg, args...)
+}
+
+// publishObservations отправляет исходные наблюдения станка сессии.
+// В режиме current публикуются наблюдения, изменившиеся с прошлого опроса.
+// В режиме sample ответ /current служит исходной точкой, после которой наблюдения
+// читаются из /sample без пропусков; при перезапуске агента исходная точка выбирается заново.
+func (s *PollingService) publishObservations(ctx context.Context, conn *entities.ConnectionInfo, current *entities.MTConnectStreams) {
+	s.observationsMutex.Lock()
+	cursor, found := s.observationCursors[conn.SessionID]
+	if !found || cursor.instanceID != current.Header.InstanceId {
+		cursor = &observationCursor{instanceID: current.Header.InstanceId, lastSeen: make(map[string]string)}
+		s.observationCursors[conn.SessionID] = cursor
+		found = false
+	}
+	s.observationsMutex.Unlock()
+
+	if s.observationsCfg.Ingest == config.ObservationsIngestSample && found && cursor.nextSequence > 0 {
+		s.readSamples(ctx, conn, cursor)
+		return
+	}
+
+	for _, observation := range s.extractObservations(current, conn.MachineID) {
+		key := observation.Timestamp + "|" + observation.Value
+		if observation.Sequence > 0 {
+			key = strconv.FormatUint(observation.Sequence, 10)
+		}
+		identity := observationIdentity(observation)
+		if cursor.lastSeen[identity] == key {
+			continue
+		}
+		cursor.lastSeen[identity] = key
+		s.publishObservation(ctx, conn, current.Header.InstanceId, observation)
+	}
+	cursor.nextSequence = current.Header.NextSequence
+}
+
+// observationIdentity возвращает ключ наблюдения в курсоре режима current. Условие с несколькими
+// активными нарушениями передается в /current отдельными наблюдениями одного DataItem с разными
+// nativeCode и последовательностями, поэтому активации различаются по nativeCode.
+func observationIdentity(observation entities.Observation) string {
+	if observation.Category == "CONDITION" {
+		return observation.DataItemId + "|" + observation.NativeCode
+	}
+	return observation.DataItemId
+}
+
+// readSamples дочитывает наблюдения из /sample начиная с курсора
+func (s *PollingService) readSamples(ctx context.Context, conn *entities.ConnectionInfo, cursor *observationCursor) {
+	baseURL := strings.TrimSuffix(conn.Config.EndpointURL, "/") + "/sample"
+	for page := 0; page < maxSamplePages; page++ {
+		sampleURL := fmt.Sprintf("%s?from=%d&count=%d", baseURL, cursor.nextSequence, s.observationsCfg.SampleCount)
+		xmlData, err := FetchXMLContext(ctx, sampleURL)
+		var streams entities.MTConnectStreams
+		if err == nil {
+			err = xml.Unmarshal(xmlData, &streams)
+		}
+		if err != nil {
+			kind := FetchErrorParse
+			if xmlData == nil {
+				kind = FetchErrorKind(err)
 			}
-			break
+			s.metrics.FetchError(conn.SessionID, kind)
+			// Агент отвечает ошибкой, если курсор вышел за пределы буфера: начинаем заново с /current
+			s.logPollError(ctx, conn, "ошибка чтения наблюдений, курсор будет сброшен", kind, err, "url", sampleURL)
+			s.resetObservationCursor(conn.SessionID)
+			return
+		}
+		if streams.Header.InstanceId != cursor.instanceID {
+			s.resetObservationCursor(conn.SessionID)
+			return
+		}
+		if streams.Header.FirstSequence > cursor.nextSequence {
+			pollLog.WarnContext(ctx, "буфер агента перезаписан, часть наблюдений потеряна", logging.Session(conn),
+				"lost", streams.Header.FirstSequence-cursor.nextSequence)
+		}
+
+		for _, observation := range s.extractObservations(&streams, conn.MachineID) {
+			s.publishObservation(ctx, conn, streams.Header.InstanceId, observation)
+		}
+		if streams.Header.NextSequence <= cursor.nextSequence {
+			return
+		}
+		cursor.nextSequence = streams.Header.NextSequence
+		if cursor.nextSequence > streams.Header.LastSequence {
+			return
 		}
 	}
 }
 
+func (s *PollingService) resetObservationCursor(sessionID string) {
+	s.observationsMutex.Lock()
+	delete(s.observationCursors, sessionID)
+	s.observationsMutex.Unlock()
+}
+
+func (s *PollingService) extractObservations(streams *entities.MTConnectStreams, machineID string) []entities.Observation {
+	s.metadataMutex.RLock()
+	defer s.metadataMutex.RUnlock()
+	return ExtractObservations(streams, s.deviceMetadataStore)[machineID]
+}
+
+func (s *PollingService) publishObservation(ctx context.Context, conn *entities.ConnectionInfo, instanceID string, observation entities.Observation) {
+	position := entities.StreamPosition{AgentInstanceID: instanceID, FirstSequence: observation.Sequence, LastSequence: observation.Sequence}
+	s.publish(ctx, conn, position, entities.MessageTypeObservation, observation)
+}
+
+// publishMachineData отправляет снимок в синки с учетом режима публикации.
+// В режиме on_change снимок сравнивается с последним опубликованным состоянием из репозитория:
+// сообщение отправляется только при изменениях (с учетом зон нечувствительности),
+// а раз в KeyframeIntervalSec отправляется полное состояние.
+func (s *PollingService) publishMachineData(ctx context.Context, conn *entities.ConnectionInfo, position entities.StreamPosition, machineData entities.MachineData) {
+	if s.publishCfg.Mode != config.PublishModeOnChange {
+		s.publish(ctx, conn, position, entities.MessageTypeSnapshot, machineData)
+		return
+	}
+
+	now := time.Now()
+	fields := entities.FlattenMachineData(machineData)
+	published, found := s.repo.GetPublished(machineData.MachineId)
+	keyframeInterval := time.Duration(s.publishCfg.KeyframeIntervalSec) * time.Second
+
+	if !found || now.Sub(published.KeyframeAt) >= keyframeInterval {
+		if s.publish(ctx, conn, position, entities.MessageTypeSnapshot, machineData) {
+			s.repo.SetPublished(machineData.MachineId, entities.PublishedState{Fields: fields, KeyframeAt: now, PublishedAt: now})
+		}
+		return
+	}
+
+	changed, removed := s.changeDetector.Diff(published.Fields, fields)
+	if len(changed) == 0 && len(removed) == 0 {
+		return
+	}
+
+	if !s.publishCfg.Deltas {
+		if s.publish(ctx, conn, position, entities.MessageTypeSnapshot, machineData) {
+			s.repo.SetPublished(machineData.MachineId, entities.PublishedState{Fields: fields, KeyframeAt: published.KeyframeAt, PublishedAt: now})
+		}
+		return
+	}
+
+	delta := entities.MachineDataDelta{
+		MachineId: machineData.MachineId,
+		Id:        machineData.Id,
+		Timestamp: machineData.Timestamp,
+		Changed:   changed,
+		Removed:   removed,
+	}
+	if !s.publish(ctx, conn, position, entities.MessageTypeDelta, delta) {
+		return
+	}
+	// Эталон обновляется только по отправленным полям, чтобы медленный дрейф ниже зоны
+	// нечувствительности накапливался относительно последнего значения, известного потребителю
+	reference := make(map[string]interface{}, len(published.Fields))
+	for path, value := range published.Fields {
+		reference[path] = value
+	}
+	for path, value := range changed {
+		reference[path] = value
+	}
+	for _, path := range removed {
+		delete(reference, path)
+	}
+	s.repo.SetPublished(machineData.MachineId, entities.PublishedState{Fields: reference, KeyframeAt: published.KeyframeAt, PublishedAt: now})
+}
+
+// publish передает сообщение в очереди синков, возвращает true, если сообщение принято.
+// Доставка выполняется синками асинхронно и здесь не подтверждается.
+// Контекст трассировки ctx сохраняется в заголовках сообщения.
+func (s *PollingService) publish(ctx context.Context, conn *entities.ConnectionInfo, position entities.StreamPosition, messageType string, payload interface{}) bool {
+	msg := &entities.Message{
+		ID:              uuid.New().String(),
+		Type:            messageType,
+		Key:             conn.MachineID,
+		SessionID:       conn.SessionID,
+		MachineID:       conn.MachineID,
+		Manufacturer:    conn.Config.Manufacturer,
+		EndpointURL:     conn.Config.EndpointURL,
+		Timestamp:       time.Now().UTC(),
+		AgentInstanceID: position.AgentInstanceID,
+		FirstSequence:   position.FirstSequence,
+		LastSequence:    position.LastSequence,
+		Payload:         payload,
+	}
+	injectTraceContext(ctx, msg)
+	if err := s.producer.Produce(ctx, msg); err != nil {
+		pollLog.ErrorContext(ctx, "не удалось отправить сообщение в синки", logging.Session(conn), "messageType", messageType, logging.Err(err))
+		return false
+	}
+	return true
+}
+
+// PublishLifecycleEvent отправляет в синки событие жизненного цикла подключения
+func (s *PollingService) PublishLifecycleEvent(conn *entities.ConnectionInfo, eventType, message string, attributes map[string]string) {
+	event := entities.LifecycleEvent{
+		EventType:    eventType,
+		SessionID:    conn.SessionID,
+		MachineId:    conn.MachineID,
+		Manufacturer: conn.Config.Manufacturer,
+		EndpointURL:  conn.Config.EndpointURL,
+		Timestamp:    time.Now().UTC(),
+		Message:      message,
+		Attributes:   attributes,
+	}
+	s.hub.Publish(entities.StreamEvent{Event: entities.StreamEventLifecycle, SessionID: conn.SessionID, MachineID: conn.MachineID, Data: event})
+	s.publish(context.Background(), conn, entities.StreamPosition{}, entities.MessageTypeLifecycle, event)
+}
+
+// trackAgentState отправляет события при потере и восстановлении связи с агентом и при его перезапуске.
+// Первый успешный опрос сессии считается исходным состоянием и событий не порождает.
+func (s *PollingService) trackAgentState(conn *entities.ConnectionInfo, fetchErr error, instanceID string) {
+	s.agentStatesMutex.Lock()
+	state, known := s.agentStates[conn.SessionID]
+	if !known {
+		state = &agentState{reachable: true}
+		s.agentStates[conn.SessionID] = state
+	}
+	wasReachable, previousInstance := state.reachable, state.instanceID
+	state.reachable = fetchErr == nil
+	if instanceID != "" {
+		state.instanceID = instanceID
+	}
+	s.agentStatesMutex.Unlock()
+
+	switch {
+	case fetchErr != nil && wasReachable:
+		s.PublishLifecycleEvent(conn, entities.LifecycleMachineUnreachable, fetchErr.Error(), nil)
+	case fetchErr == nil && !wasReachable:
+		s.PublishLifecycleEvent(conn, entities.LifecycleMachineRecovered, "", nil)
+	}
+	if fetchErr == nil && previousInstance != "" && instanceID != "" && instanceID != previousInstance {
+		s.PublishLifecycleEvent(conn, entities.LifecycleAgentRestarted, "", map[string]string{
+			"previousInstanceId": previousInstance,
+			"instanceId":         instanceID,
+		})
+	}
+}
+
+// publishAlarmEvents сравнивает условия снимка с реестром активных аварий и отправляет события в синки
+func (s *PollingService) publishAlarmEvents(ctx context.Context, conn *entities.ConnectionInfo, position entities.StreamPosition, machineData entities.MachineData) {
+	for _, event := range s.alarmSvc.ProcessMachineData(conn.SessionID, machineData) {
+		s.hub.Publish(entities.StreamEvent{Event: entities.StreamEventAlarm, SessionID: conn.SessionID, MachineID: conn.MachineID, Data: event})
+		s.publish(ctx, conn, position, entities.MessageTypeAlarm, event)
+	}
+}
+
 func (s *PollingService) fetchAndParseProbe(endpointURL string) error {
 	probeURL := strings.TrimSuffix(endpointURL, "/") + "/probe"
-	log.Printf("Загрузка метаданных с %s", probeURL)
+	pollLog.Debug("загрузка метаданных /probe", "url", probeURL)
 
 	xmlData, err := FetchXML(probeURL)
 	if err != nil {
@@ -230,7 +693,7 @@ func (s *PollingService) fetchAndParseProbe(endpointURL string) error {
 
 	var devices entities.MTConnectDevices
 	if err := xml.Unmarshal(xmlData, &devices); err != nil {
-		return fmt.Errorf("не удалось распарсить /probe XML с %s: %w", probeURL, err)
+		return entities.NewError(entities.ErrorCodeProbeInvalid, "не удалось распарсить /probe XML с %s: %w", probeURL, err)
 	}
 
 	for _, device := range devices.Devices {
@@ -243,19 +706,26 @@ func (s *PollingService) fetchAndParseProbe(endpointURL string) error {
 			s.deviceMetadataStore[strings.ToLower(item.ID)] = entities.DataItemMetadata{
 				ID: item.ID, Name: item.Name, ComponentId: device.ID, ComponentName: device.Name,
 				ComponentType: "Device", Category: item.Category, Type: item.Type, SubType: item.SubType,
+				ComponentPath: deviceId,
 			}
 			s.metadataMutex.Unlock()
 		}
 		if device.ComponentList != nil {
-			s.extractComponentMetadata(device.ComponentList.Components, deviceId)
+			s.extractComponentMetadata(device.ComponentList.Components, deviceId, deviceId)
 		}
 	}
 	return nil
 }
 
-func (s *PollingService) extractComponentMetadata(components []entities.ProbeComponent, deviceId string) {
+// extractComponentMetadata обходит дерево компонентов; parentPath - путь родителя от устройства
+func (s *PollingService) extractComponentMetadata(components []entities.ProbeComponent, deviceId, parentPath string) {
 	for _, comp := range components {
 		componentType := strings.ToUpper(comp.XMLName.Local)
+		componentName := comp.Name
+		if componentName == "" {
+			componentName = comp.ID
+		}
+		componentPath := fmt.Sprintf("%s/%s[%s]", parentPath, comp.XMLName.Local, componentName)
 		isAxisOrSpindle := componentType == "LINEAR" || componentType == "ROTARY"
 
 		for _, item := range comp.DataItems {
@@ -264,6 +734,7 @@ func (s *PollingService) extractComponentMetadata(components []entities.ProbeCom
 			s.deviceMetadataStore[lowerId] = entities.DataItemMetadata{
 				ID: item.ID, Name: item.Name, ComponentId: comp.ID, ComponentName: comp.Name,
 				ComponentType: strings.ToLower(comp.XMLName.Local), Category: item.Category, Type: item.Type, SubType: item.SubType,
+				ComponentPath: componentPath,
 			}
 			s.metadataMutex.Unlock()
 
@@ -286,7 +757,7 @@ func (s *PollingService) extractComponentMetadata(components []entities.ProbeCom
 			}
 		}
 		if comp.ComponentList != nil {
-			s.extractComponentMetadata(comp.ComponentList.Components, deviceId)
+			s.extractComponentMetadata(comp.ComponentList.Components, deviceId, componentPath)
 		}
 	}
 }
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"MTConnect/internal/adapters/repositories/datastore"
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingProducer запоминает сообщения, отправленные в синки
+type recordingProducer struct {
+	mu       sync.Mutex
+	messages []*entities.Message
+}
+
+func (p *recordingProducer) Produce(_ context.Context, msg *entities.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func (p *recordingProducer) Close() error { return nil }
+
+func (p *recordingProducer) ofType(messageType string) []*entities.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var messages []*entities.Message
+	for _, msg := range p.messages {
+		if msg.Type == messageType {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObservePoll(string, string, time.Duration)             {}
+func (nopMetrics) FetchError(string, string)                             {}
+func (nopMetrics) ObserveParse(string, time.Duration)                    {}
+func (nopMetrics) DataItems(string, int, int)                            {}
+func (nopMetrics) ObserveProduce(string, string, time.Duration, error)   {}
+func (nopMetrics) ObserveHTTPRequest(string, string, int, time.Duration) {}
+func (nopMetrics) ForgetSession(string)                                  {}
+func (nopMetrics) Handler() http.Handler                                 { return http.NotFoundHandler() }
+
+// currentWithTwoActivations - ответ /current с двумя активными нарушениями одного условия
+const currentWithTwoActivations = `<?xml version="1.0"?>
+<MTConnectStreams>
+  <Header instanceId="1700" firstSequence="1" lastSequence="20" nextSequence="21"/>
+  <Streams>
+    <DeviceStream name="Mazak" uuid="mazak-uuid">
+      <ComponentStream component="Controller" name="controller" componentId="cont">
+        <Events>
+          <Execution dataItemId="exec" sequence="15" timestamp="2024-01-01T00:00:00Z">ACTIVE</Execution>
+        </Events>
+        <Condition>
+          <Fault dataItemId="sys" sequence="17" timestamp="2024-01-01T00:00:01Z" nativeCode="E1" type="SYSTEM">Overheat</Fault>
+          <Warning dataItemId="sys" sequence="18" timestamp="2024-01-01T00:00:02Z" nativeCode="E2" type="SYSTEM">Low oil</Warning>
+        </Condition>
+      </ComponentStream>
+    </DeviceStream>
+  </Streams>
+</MTConnectStreams>`
+
+func newTestPollingService(producer *recordingProducer) *PollingService {
+	cfg := &config.AppConfig{
+		Publish:      config.PublishConfig{Mode: config.PublishModeAlways},
+		Observations: config.ObservationsConfig{Enabled: true, Ingest: config.ObservationsIngestCurrent},
+		Stream:       config.StreamConfig{HistorySize: 100},
+	}
+	return NewPollingService(cfg, datastore.NewDataStore(), producer, NewAlarmService(), NewStreamHub(cfg), nopMetrics{}).(*PollingService)
+}
+
+func TestPublishObservationsCurrentDoesNotRepublishConditionActivations(t *testing.T) {
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(currentWithTwoActivations))
+	}))
+	defer agent.Close()
+
+	producer := &recordingProducer{}
+	s := newTestPollingService(producer)
+	conn := &entities.ConnectionInfo{SessionID: "s1", MachineID: "Mazak", Config: entities.ConnectionConfig{EndpointURL: agent.URL}}
+
+	s.processSingleEndpoint(agent.URL+"/current", conn)
+	first := producer.ofType(entities.MessageTypeObservation)
+	if len(first) != 3 {
+		t.Fatalf("первый опрос: опубликовано %d наблюдений, ожидалось 3", len(first))
+	}
+	codes := map[string]bool{}
+	for _, msg := range first {
+		observation := msg.Payload.(entities.Observation)
+		if observation.DataItemId == "sys" {
+			codes[observation.NativeCode] = true
+		}
+	}
+	if !codes["E1"] || !codes["E2"] {
+		t.Fatalf("первый опрос: опубликованы активации %v, ожидались E1 и E2", codes)
+	}
+
+	s.processSingleEndpoint(agent.URL+"/current", conn)
+	if again := producer.ofType(entities.MessageTypeObservation); len(again) != len(first) {
+		t.Fatalf("второй опрос того же /current опубликовал %d наблюдений повторно", len(again)-len(first))
+	}
+}
+
+func TestPublishMachineDataTracksPublishedStateOnlyOnChange(t *testing.T) {
+	data := entities.MachineData{MachineId: "Mazak", Id: "d1", MachineState: "ACTIVE"}
+	conn := &entities.ConnectionInfo{SessionID: "s1", MachineID: "Mazak"}
+
+	producer := &recordingProducer{}
+	s := newTestPollingService(producer)
+	for i := 0; i < 2; i++ {
+		s.publishMachineData(context.Background(), conn, entities.StreamPosition{}, data)
+	}
+	if got := len(producer.ofType(entities.MessageTypeSnapshot)); got != 2 {
+		t.Errorf("режим always: опубликовано %d снимков, ожидалось 2", got)
+	}
+	if _, found := s.repo.GetPublished("Mazak"); found {
+		t.Error("режим always не должен хранить опубликованное состояние")
+	}
+
+	producer = &recordingProducer{}
+	s = newTestPollingService(producer)
+	s.publishCfg = config.PublishConfig{Mode: config.PublishModeOnChange, KeyframeIntervalSec: 60}
+	for i := 0; i < 2; i++ {
+		s.publishMachineData(context.Background(), conn, entities.StreamPosition{}, data)
+	}
+	if got := len(producer.ofType(entities.MessageTypeSnapshot)); got != 1 {
+		t.Errorf("режим on_change: опубликовано %d снимков, ожидался 1", got)
+	}
+	if published, found := s.repo.GetPublished("Mazak"); !found || published.Fields["MachineState"] != "ACTIVE" {
+		t.Errorf("режим on_change: опубликованное состояние %v", published.Fields)
+	}
+}
+
+func TestStopPollingDoesNotHoldLockWhileCycleRuns(t *testing.T) {
+	requested := make(chan struct{}, 1)
+	release := make(chan struct{})
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		select {
+		case requested <- struct{}{}:
+		default:
+		}
+		<-release
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(currentWithTwoActivations))
+	}))
+	defer agent.Close()
+	defer close(release)
+
+	producer := &recordingProducer{}
+	s := newTestPollingService(producer)
+	conn := &entities.ConnectionInfo{SessionID: "s1", MachineID: "Mazak", Config: entities.ConnectionConfig{EndpointURL: agent.URL}}
+	if err := s.StartPollingForMachine(conn, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	<-requested
+
+	stopped := make(chan struct{})
+	go func() {
+		_ = s.StopPollingForMachine("s1")
+		close(stopped)
+	}()
+
+	// Пока цикл опроса ждет агента, остановка ждет его без pollsMutex
+	detached := make(chan struct{})
+	go func() {
+		for s.ActivePolls() != 0 {
+			time.Sleep(time.Millisecond)
+		}
+		close(detached)
+	}()
+	select {
+	case <-detached:
+	case <-time.After(2 * time.Second):
+		t.Fatal("pollsMutex удерживается, пока остановка ждет завершения цикла опроса")
+	}
+	if len(producer.ofType(entities.MessageTypeLifecycle)) != 1 {
+		t.Error("событие остановки опубликовано до завершения цикла опроса")
+	}
+
+	release <- struct{}{}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("остановка опроса не завершилась")
+	}
+	events := producer.ofType(entities.MessageTypeLifecycle)
+	if len(events) != 2 || events[1].Payload.(entities.LifecycleEvent).EventType != entities.LifecyclePollingStopped {
+		t.Errorf("события жизненного цикла: %d, последним ожидалось %s", len(events), entities.LifecyclePollingStopped)
+	}
+}
+
+// blockingProducer задерживает отправку сообщений, пока не закрыт release
+type blockingProducer struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingProducer) Produce(context.Context, *entities.Message) error {
+	select {
+	case p.entered <- struct{}{}:
+	default:
+	}
+	<-p.release
+	return nil
+}
+
+func (p *blockingProducer) Close() error { return nil }
+
+func TestStartPollingPublishesEventOutsideLock(t *testing.T) {
+	producer := &blockingProducer{entered: make(chan struct{}, 1), release: make(chan struct{})}
+	cfg := &config.AppConfig{Publish: config.PublishConfig{Mode: config.PublishModeAlways}, Stream: config.StreamConfig{HistorySize: 100}}
+	s := NewPollingService(cfg, datastore.NewDataStore(), producer, NewAlarmService(), NewStreamHub(cfg), nopMetrics{}).(*PollingService)
+	conn := &entities.ConnectionInfo{SessionID: "s1", MachineID: "Mazak", Config: entities.ConnectionConfig{EndpointURL: "http://127.0.0.1:1"}}
+
+	started := make(chan error, 1)
+	go func() { started <- s.StartPollingForMachine(conn, time.Hour) }()
+	<-producer.entered
+
+	// Пока синки принимают событие запуска, pollsMutex свободен
+	active := make(chan int, 1)
+	go func() { active <- s.ActivePolls() }()
+	select {
+	case n := <-active:
+		if n != 1 {
+			t.Errorf("активных опросов: %d, ожидался 1", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("pollsMutex удерживается во время публикации события запуска опроса")
+	}
+
+	close(producer.release)
+	if err := <-started; err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StopPollingForMachine("s1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJoinStartErrorsKeepsErrorCode(t *testing.T) {
+	exists := entities.NewError(entities.ErrorCodeAlreadyExists, "опрос для сессии 's1' уже запущен")
+	unreachable := entities.NewError(entities.ErrorCodeAgentUnreachable, "агент не отвечает")
+	tests := []struct {
+		name string
+		errs []error
+		want entities.ErrorCode
+	}{
+		{"одинаковые коды", []error{exists, exists}, entities.ErrorCodeAlreadyExists},
+		{"агент недоступен", []error{unreachable}, entities.ErrorCodeAgentUnreachable},
+		{"разные коды", []error{exists, unreachable}, entities.ErrorCodeInternal},
+	}
+	for _, test := range tests {
+		err := joinStartErrors(test.errs)
+		if got := entities.ErrorCodeOf(err); got != test.want {
+			t.Errorf("%s: код %s, ожидался %s", test.name, got, test.want)
+		}
+		for _, cause := range test.errs {
+			if !errors.Is(err, cause) {
+				t.Errorf("%s: причина '%v' потеряна", test.name, cause)
+			}
+		}
+	}
+	if err := joinStartErrors(nil); err != nil {
+		t.Errorf("без ошибок получено %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrSlowConsumer - подписчик не успевал разбирать события и был отключен
+var ErrSlowConsumer = errors.New("клиент не успевает получать события, подписка закрыта")
+
+// StreamHub рассылает события опроса подписчикам SSE и WebSocket и хранит
+// последние события для возобновления потока по Last-Event-ID
+type StreamHub struct {
+	cfg config.StreamConfig
+
+	mu          sync.Mutex
+	nextID      uint64
+	history     []entities.StreamEvent
+	subscribers map[*streamSubscription]struct{}
+	// projecting - число подписчиков с фильтром полей; без них снимок не кодируется
+	projecting atomic.Int32
+}
+
+func NewStreamHub(cfg *config.AppConfig) interfaces.StreamHub {
+	return &StreamHub{
+		cfg: cfg.Stream,
+		// Идентификаторы начинаются со времени запуска, чтобы Last-Event-ID клиента,
+		// полученный до перезапуска сервиса, не совпал с новыми событиями
+		nextID:      uint64(time.Now().UnixMicro()),
+		history:     make([]entities.StreamEvent, 0, cfg.Stream.HistorySize),
+		subscribers: make(map[*streamSubscription]struct{}),
+	}
+}
+
+// Publish присваивает событию идентификатор и рассылает его подписчикам без блокировки:
+// подписчик с заполненной очередью отключается. Снимок для подписчиков с фильтром полей
+// кодируется один раз на событие и вне блокировки, чтобы их число не замедляло цикл опроса.
+func (h *StreamHub) Publish(event entities.StreamEvent) {
+	var document map[string]json.RawMessage
+	encoded := h.projecting.Load() > 0
+	if encoded {
+		document = machineDataDocument(event.Data)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	event.ID = h.nextID
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	if len(h.history) == h.cfg.HistorySize {
+		copy(h.history, h.history[1:])
+		h.history = h.history[:len(h.history)-1]
+	}
+	h.history = append(h.history, event)
+
+	for sub := range h.subscribers {
+		if !sub.matches(event) {
+			continue
+		}
+		if len(sub.fields) > 0 && !encoded {
+			// Подписчик с фильтром полей появился после проверки
+			document, encoded = machineDataDocument(event.Data), true
+		}
+		select {
+		case sub.events <- sub.project(event, document):
+		default:
+			h.removeUnsafe(sub, ErrSlowConsumer)
+		}
+	}
+}
+
+// Subscribe регистрирует подписчика и передает ему события из истории после lastEventID
+func (h *StreamHub) Subscribe(filter entities.StreamFilter, lastEventID uint64) (interfaces.StreamSubscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.subscribers) >= h.cfg.MaxClients {
+		return nil, entities.NewError(entities.ErrorCodeUnavailable, "превышено максимальное количество подписчиков: %d", h.cfg.MaxClients)
+	}
+
+	sub := &streamSubscription{
+		hub:      h,
+		sessions: toStringSet(filter.SessionIDs),
+		kinds:    toStringSet(filter.Events),
+		fields:   toStringSet(filter.Fields),
+	}
+	var replay []entities.StreamEvent
+	if lastEventID > 0 {
+		for _, event := range h.history {
+			if event.ID > lastEventID && sub.matches(event) {
+				var document map[string]json.RawMessage
+				if len(sub.fields) > 0 {
+					document = machineDataDocument(event.Data)
+				}
+				replay = append(replay, sub.project(event, document))
+			}
+		}
+	}
+	sub.events = make(chan entities.StreamEvent, h.cfg.BufferSize+len(replay))
+	for _, event := range replay {
+		sub.events <- event
+	}
+	h.subscribers[sub] = struct{}{}
+	if len(sub.fields) > 0 {
+		h.projecting.Add(1)
+	}
+	return sub, nil
+}
+
+func (h *StreamHub) removeUnsafe(sub *streamSubscription, reason error) {
+	if _, ok := h.subscribers[sub]; !ok {
+		return
+	}
+	delete(h.subscribers, sub)
+	if len(sub.fields) > 0 {
+		h.projecting.Add(-1)
+	}
+	sub.err = reason
+	close(sub.events)
+}
+
+type streamSubscription struct {
+	hub      *StreamHub
+	events   chan entities.StreamEvent
+	err      error
+	sessions map[string]bool
+	kinds    map[string]bool
+	fields   map[string]bool
+}
+
+func (s *streamSubscription) Events() <-chan entities.StreamEvent {
+	return s.events
+}
+
+func (s *streamSubscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+func (s *streamSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.removeUnsafe(s, nil)
+}
+
+func (s *streamSubscription) matches(event entities.StreamEvent) bool {
+	if len(s.sessions) > 0 && !s.sessions[event.SessionID] {
+		return false
+	}
+	return len(s.kinds) == 0 || s.kinds[event.Event]
+}
+
+// project сокращает MachineData до выбранных полей. document - снимок события, закодированный
+// machineDataDocument; он общий для всех подписчиков и не изменяется.
+func (s *streamSubscription) project(event entities.StreamEvent, document map[string]json.RawMessage) entities.StreamEvent {
+	if len(s.fields) == 0 || document == nil {
+		return event
+	}
+	projected := make(map[string]json.RawMessage, len(s.fields)+3)
+	for field, value := range document {
+		if s.fields[field] || field == "MachineId" || field == "Id" || field == "Timestamp" {
+			projected[field] = value
+		}
+	}
+	event.Data = projected
+	return event
+}
+
+// machineDataDocument кодирует снимок станка в документ верхнего уровня; для остальных данных возвращает nil
+func machineDataDocument(data interface{}) map[string]json.RawMessage {
+	machineData, ok := data.(entities.MachineData)
+	if !ok {
+		return nil
+	}
+	raw, err := json.Marshal(machineData)
+	if err != nil {
+		return nil
+	}
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil
+	}
+	return document
+}
+
+func toStringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
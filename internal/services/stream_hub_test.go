@@ -0,0 +1,63 @@
+package services
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"encoding/json"
+	"testing"
+)
+
+func TestStreamHubProjectsFieldsPerSubscriber(t *testing.T) {
+	hub := NewStreamHub(&config.AppConfig{Stream: config.StreamConfig{BufferSize: 10, HistorySize: 10, MaxClients: 10}}).(*StreamHub)
+	subscribe := func(fields ...string) <-chan entities.StreamEvent {
+		sub, err := hub.Subscribe(entities.StreamFilter{Fields: fields}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(sub.Close)
+		return sub.Events()
+	}
+	all := subscribe()
+	state := subscribe("MachineState")
+	program := subscribe("ProgramMode")
+
+	data := entities.MachineData{MachineId: "Mazak", Id: "d1", MachineState: "ACTIVE", ProgramMode: "MEMORY"}
+	hub.Publish(entities.StreamEvent{Event: entities.StreamEventMachineData, SessionID: "s1", Data: data})
+
+	if event := <-all; event.Data.(entities.MachineData).MachineState != "ACTIVE" {
+		t.Errorf("подписчик без фильтра получил %v", event.Data)
+	}
+	for _, test := range []struct {
+		events <-chan entities.StreamEvent
+		field  string
+		value  string
+		absent string
+	}{
+		{state, "MachineState", `"ACTIVE"`, "ProgramMode"},
+		{program, "ProgramMode", `"MEMORY"`, "MachineState"},
+	} {
+		document, ok := (<-test.events).Data.(map[string]json.RawMessage)
+		if !ok {
+			t.Fatalf("подписчик с полем %s получил данные без проекции", test.field)
+		}
+		if string(document[test.field]) != test.value || string(document["MachineId"]) != `"Mazak"` {
+			t.Errorf("проекция %s: %v", test.field, document)
+		}
+		if _, ok := document[test.absent]; ok {
+			t.Errorf("проекция %s содержит поле %s другого подписчика", test.field, test.absent)
+		}
+	}
+
+	// Возобновление по Last-Event-ID получает ту же проекцию из истории
+	replay, err := hub.Subscribe(entities.StreamFilter{Fields: []string{"MachineState"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if document, ok := (<-replay.Events()).Data.(map[string]json.RawMessage); !ok || string(document["MachineState"]) != `"ACTIVE"` {
+		t.Errorf("событие из истории без проекции")
+	}
+	replay.Close()
+	if n := hub.projecting.Load(); n != 2 {
+		t.Errorf("подписчиков с фильтром полей: %d, ожидалось 2", n)
+	}
+}
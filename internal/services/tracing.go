@@ -0,0 +1,52 @@
+package services
+
+import (
+	"MTConnect/internal/domain/entities"
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer возвращает трассировщик спанов цикла опроса. Провайдер запрашивается при каждом спане,
+// а не один раз при загрузке пакета, чтобы спаны попадали в провайдер, установленный последним.
+func tracer() trace.Tracer {
+	return otel.Tracer("MTConnect/internal/services")
+}
+
+// Атрибуты спанов, общие для опроса и публикации
+const (
+	attrSessionID   = attribute.Key("mtconnect.session_id")
+	attrMachineID   = attribute.Key("mtconnect.machine_id")
+	attrEndpointURL = attribute.Key("mtconnect.endpoint_url")
+)
+
+// sessionAttributes описывает сессию, к которой относится спан
+func sessionAttributes(conn *entities.ConnectionInfo) trace.SpanStartOption {
+	return trace.WithAttributes(
+		attrSessionID.String(conn.SessionID),
+		attrMachineID.String(conn.MachineID),
+		attrEndpointURL.String(conn.Config.EndpointURL),
+	)
+}
+
+// recordSpanError отмечает спан как завершившийся ошибкой
+func recordSpanError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// injectTraceContext сохраняет контекст трассировки в заголовках сообщения (traceparent, tracestate),
+// чтобы синки передали его потребителям вместе с сообщением
+func injectTraceContext(ctx context.Context, msg *entities.Message) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]string)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(msg.Headers))
+}
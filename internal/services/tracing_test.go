@@ -0,0 +1,103 @@
+package services
+
+import (
+	"MTConnect/internal/adapters/tracing/tracingtest"
+	"MTConnect/internal/domain/entities"
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// TestPollingTraceExport проверяет, что цикл опроса экспортируется коллектору по OTLP/HTTP
+// спанами этапов с атрибутами сессии, а контекст трассировки передается агенту и в заголовки сообщений
+func TestPollingTraceExport(t *testing.T) {
+	collector := tracingtest.NewCollector(t)
+	stopTracing := collector.Start(t, "mtconnect-test")
+
+	agentTraceparents := map[string]bool{}
+	var agentMu sync.Mutex
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agentMu.Lock()
+		agentTraceparents[r.Header.Get("traceparent")] = true
+		agentMu.Unlock()
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(currentWithTwoActivations))
+	}))
+	defer agent.Close()
+
+	producer := &recordingProducer{}
+	s := newTestPollingService(producer)
+	conn := &entities.ConnectionInfo{SessionID: "session-1", MachineID: "Mazak", Config: entities.ConnectionConfig{EndpointURL: agent.URL}}
+	if err := s.StartPollingForMachine(conn, 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(producer.ofType(entities.MessageTypeSnapshot)) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := s.StopPollingForMachine(conn.SessionID); err != nil {
+		t.Fatal(err)
+	}
+	// Остановка провайдера отправляет коллектору все завершенные спаны
+	stopTracing()
+
+	if collector.ServiceName() != "mtconnect-test" {
+		t.Errorf("service.name ресурса = '%s'", collector.ServiceName())
+	}
+	polls := map[string]*tracepb.Span{}
+	for _, span := range collector.SpansNamed("poll") {
+		polls[hex.EncodeToString(span.GetSpanId())] = span
+	}
+	if len(polls) == 0 {
+		t.Fatal("коллектор не получил спан poll")
+	}
+
+	// Снимок отправлен в контексте спана poll, который несет атрибуты сессии
+	snapshots := producer.ofType(entities.MessageTypeSnapshot)
+	if len(snapshots) == 0 {
+		t.Fatal("снимок не опубликован")
+	}
+	var poll *tracepb.Span
+	for _, span := range polls {
+		want := "00-" + hex.EncodeToString(span.GetTraceId()) + "-" + hex.EncodeToString(span.GetSpanId()) + "-01"
+		if snapshots[0].Headers["traceparent"] == want {
+			poll = span
+		}
+	}
+	if poll == nil {
+		t.Fatalf("traceparent снимка '%s' не относится ни к одному спану poll", snapshots[0].Headers["traceparent"])
+	}
+	for key, want := range map[string]string{"mtconnect.session_id": "session-1", "mtconnect.machine_id": "Mazak", "mtconnect.endpoint_url": agent.URL} {
+		if got, _ := tracingtest.Attribute(poll.GetAttributes(), key); got != want {
+			t.Errorf("атрибут спана poll %s = '%s', ожидалось '%s'", key, got, want)
+		}
+	}
+
+	// Этапы опроса - дочерние спаны poll
+	stages := map[string]*tracepb.Span{}
+	for _, name := range []string{"FetchXML", "xml.Unmarshal", "MapToMachineData", "repo.Set"} {
+		for _, span := range collector.SpansNamed(name) {
+			if bytes.Equal(span.GetParentSpanId(), poll.GetSpanId()) {
+				stages[name] = span
+			}
+		}
+		if stages[name] == nil {
+			t.Errorf("коллектор не получил спан %s, дочерний для poll", name)
+		}
+	}
+	// Запрос к агенту продолжает трассу спана FetchXML
+	if fetch := stages["FetchXML"]; fetch != nil {
+		want := "00-" + hex.EncodeToString(fetch.GetTraceId()) + "-" + hex.EncodeToString(fetch.GetSpanId()) + "-01"
+		agentMu.Lock()
+		if !agentTraceparents[want] {
+			t.Errorf("запрос к агенту без traceparent '%s'", want)
+		}
+		agentMu.Unlock()
+	}
+}
@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+)
+
+type AlarmUsecase struct {
+	connSvc  interfaces.ConnectionService
+	alarmSvc interfaces.AlarmService
+}
+
+func NewAlarmUsecase(connSvc interfaces.ConnectionService, alarmSvc interfaces.AlarmService) interfaces.AlarmUsecase {
+	return &AlarmUsecase{
+		connSvc:  connSvc,
+		alarmSvc: alarmSvc,
+	}
+}
+
+func (u *AlarmUsecase) GetActiveAlarms(sessionID string) ([]entities.ActiveAlarm, error) {
+	if _, found := u.connSvc.GetConnection(sessionID); !found {
+		return nil, entities.NewError(entities.ErrorCodeNotFound, "сессия '%s' не найдена", sessionID)
+	}
+	return u.alarmSvc.GetActiveAlarms(sessionID), nil
+}
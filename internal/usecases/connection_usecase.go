@@ -3,18 +3,22 @@ package usecases
 import (
 	"MTConnect/internal/domain/entities"
 	"MTConnect/internal/interfaces"
+	"sort"
+	"strings"
 	"time"
 )
 
 type ConnectionUsecase struct {
-	connSvc interfaces.ConnectionService
-	pollSvc interfaces.PollingService
+	connSvc  interfaces.ConnectionService
+	pollSvc  interfaces.PollingService
+	alarmSvc interfaces.AlarmService
 }
 
-func NewConnectionUsecase(connSvc interfaces.ConnectionService, pollSvc interfaces.PollingService) interfaces.ConnectionUsecase {
+func NewConnectionUsecase(connSvc interfaces.ConnectionService, pollSvc interfaces.PollingService, alarmSvc interfaces.AlarmService) interfaces.ConnectionUsecase {
 	return &ConnectionUsecase{
-		connSvc: connSvc,
-		pollSvc: pollSvc,
+		connSvc:  connSvc,
+		pollSvc:  pollSvc,
+		alarmSvc: alarmSvc,
 	}
 }
 
@@ -22,12 +26,101 @@ func (u *ConnectionUsecase) CreateConnection(req entities.ConnectionRequest) (*e
 	return u.connSvc.CreateConnection(req)
 }
 
+func (u *ConnectionUsecase) BulkCreateConnections(req entities.BulkConnectionRequest) ([]entities.BulkConnectionResult, error) {
+	if len(req.Connections) == 0 || len(req.Connections) > entities.MaxBulkConnections {
+		return nil, entities.NewError(entities.ErrorCodeInvalidArgument, "пакет должен содержать от 1 до %d подключений", entities.MaxBulkConnections)
+	}
+	concurrency := req.Concurrency
+	if concurrency == 0 {
+		concurrency = entities.DefaultBulkConcurrency
+	}
+	if concurrency < 0 || concurrency > entities.MaxBulkConcurrency {
+		return nil, entities.NewError(entities.ErrorCodeInvalidArgument, "concurrency должен быть от 1 до %d", entities.MaxBulkConcurrency)
+	}
+	return u.connSvc.CreateConnections(req.Connections, concurrency), nil
+}
+
+func (u *ConnectionUsecase) ExportConnections(query entities.ConnectionQuery) (entities.ConnectionExport, error) {
+	matched, err := u.selectConnections(query)
+	if err != nil {
+		return entities.ConnectionExport{}, err
+	}
+
+	export := entities.ConnectionExport{Connections: make([]entities.ConnectionRequest, 0, len(matched))}
+	for _, conn := range matched {
+		export.Connections = append(export.Connections, entities.ConnectionRequest{
+			EndpointURL:  conn.Config.EndpointURL,
+			Model:        conn.Config.Model,
+			Manufacturer: conn.Config.Manufacturer,
+			Match:        conn.Config.Match,
+		})
+	}
+	return export, nil
+}
+
 func (u *ConnectionUsecase) GetAllConnections() []*entities.ConnectionInfo {
 	return u.connSvc.GetAllConnections()
 }
 
+func (u *ConnectionUsecase) ListConnections(query entities.ConnectionQuery) (entities.ConnectionPage, error) {
+	if query.Limit == 0 {
+		query.Limit = entities.DefaultConnectionPageLimit
+	}
+	if query.Limit < 0 || query.Limit > entities.MaxConnectionPageLimit {
+		return entities.ConnectionPage{}, entities.NewError(entities.ErrorCodeInvalidArgument, "limit должен быть от 1 до %d", entities.MaxConnectionPageLimit)
+	}
+	if query.Offset < 0 {
+		return entities.ConnectionPage{}, entities.NewError(entities.ErrorCodeInvalidArgument, "offset не может быть отрицательным")
+	}
+	matched, err := u.selectConnections(query)
+	if err != nil {
+		return entities.ConnectionPage{}, err
+	}
+
+	page := entities.ConnectionPage{Total: len(matched), Limit: query.Limit, Offset: query.Offset, Items: []*entities.ConnectionInfo{}}
+	if query.Offset < len(matched) {
+		end := query.Offset + query.Limit
+		if end > len(matched) {
+			end = len(matched)
+		}
+		page.Items = matched[query.Offset:end]
+	}
+	return page, nil
+}
+
+func (u *ConnectionUsecase) GetConnection(sessionID string) (*entities.ConnectionInfo, error) {
+	conn, found := u.connSvc.GetConnection(sessionID)
+	if !found {
+		return nil, entities.NewError(entities.ErrorCodeNotFound, "сессия '%s' не найдена", sessionID)
+	}
+	return conn, nil
+}
+
+func (u *ConnectionUsecase) UpdateConnection(sessionID string, patch entities.ConnectionPatch) (*entities.ConnectionInfo, error) {
+	if patch.EndpointURL == nil && patch.Model == nil && patch.Manufacturer == nil && patch.Match == nil {
+		return nil, entities.NewError(entities.ErrorCodeInvalidArgument, "не задано ни одно изменяемое поле (EndpointURL, Model, Manufacturer, Match)")
+	}
+	previous, found := u.connSvc.GetConnection(sessionID)
+	if !found {
+		return nil, entities.NewError(entities.ErrorCodeNotFound, "сессия '%s' не найдена", sessionID)
+	}
+	updated, err := u.connSvc.UpdateConnection(sessionID, patch)
+	if err != nil {
+		return nil, err
+	}
+	// Аварии прежнего станка не относятся к новому
+	if updated.MachineID != previous.MachineID || updated.Config.EndpointURL != previous.Config.EndpointURL {
+		u.alarmSvc.ClearSession(sessionID)
+	}
+	return updated, nil
+}
+
 func (u *ConnectionUsecase) DeleteConnection(sessionID string) error {
-	return u.connSvc.DeleteConnection(sessionID)
+	if err := u.connSvc.DeleteConnection(sessionID); err != nil {
+		return err
+	}
+	u.alarmSvc.ClearSession(sessionID)
+	return nil
 }
 
 func (u *ConnectionUsecase) CheckConnection(sessionID string) (*entities.ConnectionInfo, error) {
@@ -43,3 +136,80 @@ func (u *ConnectionUsecase) StopPolling() error {
 	u.pollSvc.StopAllPolling()
 	return nil
 }
+
+// selectConnections отбирает подключения по фильтрам query и сортирует их
+func (u *ConnectionUsecase) selectConnections(query entities.ConnectionQuery) ([]*entities.ConnectionInfo, error) {
+	less, err := connectionOrder(query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	var matched []*entities.ConnectionInfo
+	for _, conn := range u.connSvc.GetAllConnections() {
+		if matchConnection(conn, query) {
+			matched = append(matched, conn)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return less(matched[i], matched[j]) })
+	return matched, nil
+}
+
+// matchConnection проверяет подключение по фильтрам запроса; строки сравниваются без учета регистра
+func matchConnection(conn *entities.ConnectionInfo, query entities.ConnectionQuery) bool {
+	if query.MachineID != "" && !strings.EqualFold(conn.MachineID, query.MachineID) {
+		return false
+	}
+	if query.Manufacturer != "" && !strings.EqualFold(conn.Config.Manufacturer, query.Manufacturer) {
+		return false
+	}
+	if query.Model != "" && !strings.EqualFold(conn.Config.Model, query.Model) {
+		return false
+	}
+	if query.Healthy != nil && conn.IsHealthy != *query.Healthy {
+		return false
+	}
+	return true
+}
+
+// connectionOrder возвращает функцию сравнения для поля сортировки; при равенстве поля порядок задает SessionID
+func connectionOrder(sortBy string) (func(a, b *entities.ConnectionInfo) bool, error) {
+	descending := strings.HasPrefix(sortBy, "-")
+	field := strings.TrimPrefix(sortBy, "-")
+	if field == "" {
+		field = entities.ConnectionSortCreatedAt
+	}
+
+	var compare func(a, b *entities.ConnectionInfo) int
+	switch field {
+	case entities.ConnectionSortCreatedAt:
+		compare = func(a, b *entities.ConnectionInfo) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case entities.ConnectionSortLastUsed:
+		compare = func(a, b *entities.ConnectionInfo) int { return a.LastUsed.Compare(b.LastUsed) }
+	case entities.ConnectionSortMachineID:
+		compare = func(a, b *entities.ConnectionInfo) int { return strings.Compare(a.MachineID, b.MachineID) }
+	case entities.ConnectionSortSessionID:
+		compare = func(a, b *entities.ConnectionInfo) int { return 0 }
+	case entities.ConnectionSortUseCount:
+		compare = func(a, b *entities.ConnectionInfo) int {
+			switch {
+			case a.UseCount < b.UseCount:
+				return -1
+			case a.UseCount > b.UseCount:
+				return 1
+			}
+			return 0
+		}
+	default:
+		return nil, entities.NewError(entities.ErrorCodeInvalidArgument, "неизвестное поле сортировки '%s', допустимы: created_at, last_used, machine_id, session_id, use_count", field)
+	}
+
+	return func(a, b *entities.ConnectionInfo) bool {
+		c := compare(a, b)
+		if c == 0 {
+			c = strings.Compare(a.SessionID, b.SessionID)
+		}
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	}, nil
+}
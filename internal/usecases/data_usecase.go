@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+)
+
+type DataUsecase struct {
+	repo    interfaces.Repository
+	connSvc interfaces.ConnectionService
+}
+
+func NewDataUsecase(repo interfaces.Repository, connSvc interfaces.ConnectionService) interfaces.DataUsecase {
+	return &DataUsecase{
+		repo:    repo,
+		connSvc: connSvc,
+	}
+}
+
+func (u *DataUsecase) GetCurrentData(sessionID string) (entities.MachineData, error) {
+	conn, found := u.connSvc.GetConnection(sessionID)
+	if !found {
+		return entities.MachineData{}, entities.NewError(entities.ErrorCodeNotFound, "сессия '%s' не найдена", sessionID)
+	}
+	data, found := u.repo.Get(conn.MachineID)
+	if !found {
+		return entities.MachineData{}, entities.NewError(entities.ErrorCodeNotFound, "данные станка '%s' еще не получены", conn.MachineID)
+	}
+	return data, nil
+}
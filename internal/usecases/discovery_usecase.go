@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"net/url"
+)
+
+type DiscoveryUsecase struct {
+	connSvc interfaces.ConnectionService
+}
+
+func NewDiscoveryUsecase(connSvc interfaces.ConnectionService) interfaces.DiscoveryUsecase {
+	return &DiscoveryUsecase{connSvc: connSvc}
+}
+
+func (u *DiscoveryUsecase) DiscoverDevices(endpointURL string) ([]entities.DiscoveredDevice, error) {
+	if err := validateEndpointURL(endpointURL); err != nil {
+		return nil, err
+	}
+	return u.connSvc.DiscoverDevices(endpointURL)
+}
+
+func (u *DiscoveryUsecase) ConnectDiscoveredDevices(req entities.DiscoverConnectRequest) ([]entities.BulkConnectionResult, error) {
+	if err := validateEndpointURL(req.EndpointURL); err != nil {
+		return nil, err
+	}
+	return u.connSvc.ConnectDevices(req.EndpointURL, req.Devices)
+}
+
+// validateEndpointURL проверяет, что адрес агента - абсолютный URL http или https
+func validateEndpointURL(endpointURL string) error {
+	parsed, err := url.Parse(endpointURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return entities.NewError(entities.ErrorCodeInvalidArgument, "адрес агента '%s' должен быть абсолютным URL http или https", endpointURL)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type HealthUsecase struct {
+	cfg         config.HealthConfig
+	connections interfaces.ConnectionService
+	polling     interfaces.PollingService
+	sinks       interfaces.SinkMonitor
+}
+
+func NewHealthUsecase(cfg config.HealthConfig, connections interfaces.ConnectionService, polling interfaces.PollingService, sinks interfaces.SinkMonitor) interfaces.HealthUsecase {
+	return &HealthUsecase{
+		cfg:         cfg,
+		connections: connections,
+		polling:     polling,
+		sinks:       sinks,
+	}
+}
+
+// GetReadiness проверяет связь синков с внешними системами, долю исправных сессий и зависшие циклы опроса.
+// Сервис не готов, если хотя бы один компонент в состоянии fail.
+func (u *HealthUsecase) GetReadiness(ctx context.Context) entities.HealthReport {
+	now := time.Now()
+	pollStates := u.polling.PollStates()
+	report := entities.HealthReport{
+		Status:    entities.HealthStatusOK,
+		Timestamp: now.UTC(),
+		Components: []entities.ComponentHealth{
+			u.checkSinks(ctx),
+			u.checkSessions(pollStates),
+			u.checkPolling(pollStates, now),
+		},
+	}
+	for _, component := range report.Components {
+		switch component.Status {
+		case entities.HealthStatusFail:
+			report.Status = entities.HealthStatusFail
+		case entities.HealthStatusDegraded:
+			if report.Status == entities.HealthStatusOK {
+				report.Status = entities.HealthStatusDegraded
+			}
+		}
+	}
+	return report
+}
+
+// checkSinks проверяет связь синков с брокерами. Недоступный синк с дисковой очередью не теряет
+// сообщения, поэтому только снижает состояние до degraded.
+func (u *HealthUsecase) checkSinks(ctx context.Context) entities.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(u.cfg.CheckTimeoutMs)*time.Millisecond)
+	defer cancel()
+	checks := u.sinks.CheckSinks(ctx)
+	component := entities.ComponentHealth{Name: entities.HealthComponentSinks, Status: entities.HealthStatusOK, Details: checks}
+	if len(checks) == 0 {
+		component.Message = "нет синков с проверкой связи"
+		return component
+	}
+
+	var failed []string
+	for _, check := range checks {
+		if check.Status == entities.HealthStatusOK {
+			continue
+		}
+		if check.Buffered {
+			failed = append(failed, check.Name+" (сообщения сохраняются в дисковую очередь)")
+			if component.Status == entities.HealthStatusOK {
+				component.Status = entities.HealthStatusDegraded
+			}
+			continue
+		}
+		failed = append(failed, check.Name)
+		component.Status = entities.HealthStatusFail
+	}
+	if len(failed) > 0 {
+		component.Message = "нет связи с синками: " + strings.Join(failed, ", ")
+	}
+	return component
+}
+
+// checkSessions сравнивает долю исправных сессий с порогом min_healthy_ratio. Сессия исправна,
+// если последняя проверка подключения успешна и, при запущенном опросе, агент отвечает на опросы.
+func (u *HealthUsecase) checkSessions(pollStates []entities.PollState) entities.ComponentHealth {
+	reachable := make(map[string]*bool, len(pollStates))
+	for _, state := range pollStates {
+		reachable[state.SessionID] = state.Reachable
+	}
+
+	connections := u.connections.GetAllConnections()
+	details := entities.SessionsHealth{Total: len(connections)}
+	for _, conn := range connections {
+		switch polled := reachable[conn.SessionID]; {
+		case !conn.IsHealthy:
+			details.Unhealthy = append(details.Unhealthy, entities.SessionHealth{SessionID: conn.SessionID, MachineID: conn.MachineID, Reason: "проверка подключения не пройдена"})
+		case polled != nil && !*polled:
+			details.Unhealthy = append(details.Unhealthy, entities.SessionHealth{SessionID: conn.SessionID, MachineID: conn.MachineID, Reason: "агент не отвечает на опросы"})
+		default:
+			details.Healthy++
+		}
+	}
+
+	component := entities.ComponentHealth{Name: entities.HealthComponentSessions, Status: entities.HealthStatusOK, Details: details}
+	if details.Total == 0 {
+		component.Message = "пул подключений пуст"
+		return component
+	}
+	ratio := float64(details.Healthy) / float64(details.Total)
+	switch {
+	case ratio < *u.cfg.MinHealthyRatio:
+		component.Status = entities.HealthStatusFail
+		component.Message = fmt.Sprintf("исправно %d из %d сессий, требуется не менее %.0f%%", details.Healthy, details.Total, *u.cfg.MinHealthyRatio*100)
+	case details.Healthy < details.Total:
+		component.Status = entities.HealthStatusDegraded
+		component.Message = fmt.Sprintf("исправно %d из %d сессий", details.Healthy, details.Total)
+	}
+	return component
+}
+
+// checkPolling ищет зависшие циклы опроса: сессии, не получившие данных за stale_intervals интервалов
+// (с момента запуска опроса, если данных еще не было), и сравнивает их долю с порогом max_stale_ratio.
+func (u *HealthUsecase) checkPolling(pollStates []entities.PollState, now time.Time) entities.ComponentHealth {
+	details := entities.PollingHealth{Active: len(pollStates)}
+	for _, state := range pollStates {
+		since := state.StartedAt
+		if state.LastData != nil {
+			since = *state.LastData
+		}
+		limit := time.Duration(u.cfg.StaleIntervals) * state.Interval
+		if idle := now.Sub(since); idle > limit {
+			details.StaleSessions = append(details.StaleSessions, entities.SessionHealth{
+				SessionID: state.SessionID,
+				MachineID: state.MachineID,
+				Reason:    fmt.Sprintf("нет данных %s при допустимых %s", idle.Round(time.Second), limit),
+				LastData:  state.LastData,
+			})
+		}
+	}
+	details.Stale = len(details.StaleSessions)
+
+	component := entities.ComponentHealth{Name: entities.HealthComponentPolling, Status: entities.HealthStatusOK, Details: details}
+	if details.Active == 0 {
+		component.Message = "опрос не запущен"
+		return component
+	}
+	switch {
+	case float64(details.Stale)/float64(details.Active) > *u.cfg.MaxStaleRatio:
+		component.Status = entities.HealthStatusFail
+		component.Message = fmt.Sprintf("зависли %d из %d циклов опроса, допускается не более %.0f%%", details.Stale, details.Active, *u.cfg.MaxStaleRatio*100)
+	case details.Stale > 0:
+		component.Status = entities.HealthStatusDegraded
+		component.Message = fmt.Sprintf("зависли %d из %d циклов опроса", details.Stale, details.Active)
+	}
+	return component
+}
@@ -1,19 +1,42 @@
 package usecases
 
-import "MTConnect/internal/interfaces"
+import (
+	"MTConnect/internal/config"
+	"MTConnect/internal/interfaces"
+)
 
 // UseCases - агрегатор всех use case интерфейсов
 type UseCases struct {
 	interfaces.ConnectionUsecase
+	interfaces.DiscoveryUsecase
+	interfaces.AlarmUsecase
+	interfaces.DataUsecase
+	interfaces.MonitoringUsecase
+	interfaces.HealthUsecase
+	interfaces.LoggingUsecase
+	interfaces.StreamUsecase
 }
 
 // NewUsecases - конструктор для UseCases
 func NewUsecases(
+	cfg *config.AppConfig,
 	repo interfaces.Repository,
 	pollSvc interfaces.PollingService,
 	connSvc interfaces.ConnectionService,
+	alarmSvc interfaces.AlarmService,
+	outboxes interfaces.OutboxMonitor,
+	sinks interfaces.SinkMonitor,
+	hub interfaces.StreamHub,
+	logLevels interfaces.LogLevelController,
 ) interfaces.Usecases {
 	return &UseCases{
-		ConnectionUsecase: NewConnectionUsecase(connSvc, pollSvc),
+		ConnectionUsecase: NewConnectionUsecase(connSvc, pollSvc, alarmSvc),
+		DiscoveryUsecase:  NewDiscoveryUsecase(connSvc),
+		AlarmUsecase:      NewAlarmUsecase(connSvc, alarmSvc),
+		DataUsecase:       NewDataUsecase(repo, connSvc),
+		MonitoringUsecase: NewMonitoringUsecase(outboxes, sinks),
+		HealthUsecase:     NewHealthUsecase(cfg.Health, connSvc, pollSvc, sinks),
+		LoggingUsecase:    NewLoggingUsecase(logLevels),
+		StreamUsecase:     NewStreamUsecase(hub),
 	}
 }
@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+)
+
+type LoggingUsecase struct {
+	levels interfaces.LogLevelController
+}
+
+func NewLoggingUsecase(levels interfaces.LogLevelController) interfaces.LoggingUsecase {
+	return &LoggingUsecase{levels: levels}
+}
+
+func (u *LoggingUsecase) GetLogLevels() entities.LogLevels {
+	return u.levels.LogLevels()
+}
+
+func (u *LoggingUsecase) SetLogLevels(update entities.LogLevelsUpdate) (entities.LogLevels, error) {
+	return u.levels.SetLogLevels(update)
+}
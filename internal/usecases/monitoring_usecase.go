@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+)
+
+type MonitoringUsecase struct {
+	outboxes interfaces.OutboxMonitor
+	sinks    interfaces.SinkMonitor
+}
+
+func NewMonitoringUsecase(outboxes interfaces.OutboxMonitor, sinks interfaces.SinkMonitor) interfaces.MonitoringUsecase {
+	return &MonitoringUsecase{
+		outboxes: outboxes,
+		sinks:    sinks,
+	}
+}
+
+func (u *MonitoringUsecase) GetOutboxStats() []entities.OutboxStats {
+	return u.outboxes.OutboxStats()
+}
+
+func (u *MonitoringUsecase) GetSinkStats() []entities.SinkStats {
+	return u.sinks.SinkStats()
+}
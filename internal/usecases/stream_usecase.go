@@ -0,0 +1,18 @@
+package usecases
+
+import (
+	"MTConnect/internal/domain/entities"
+	"MTConnect/internal/interfaces"
+)
+
+type StreamUsecase struct {
+	hub interfaces.StreamHub
+}
+
+func NewStreamUsecase(hub interfaces.StreamHub) interfaces.StreamUsecase {
+	return &StreamUsecase{hub: hub}
+}
+
+func (u *StreamUsecase) Subscribe(filter entities.StreamFilter, lastEventID uint64) (interfaces.StreamSubscription, error) {
+	return u.hub.Subscribe(filter, lastEventID)
+}
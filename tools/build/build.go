@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
 type buildTarget struct {
@@ -40,7 +41,8 @@ func main() {
 		},
 	}
 
-	log.Println("Starting build process...")
+	version := buildVersion()
+	log.Printf("Starting build process (version %s)...", version)
 
 	for _, target := range targets {
 		log.Printf("Building for %s/%s...", target.GOOS, target.GOARCH)
@@ -50,7 +52,8 @@ func main() {
 		}
 
 		outputPath := filepath.Join(target.OutputDir, target.OutputName)
-		cmd := exec.Command("go", "build", "-o", outputPath, sourcePath)
+		ldflags := "-X MTConnect/internal/buildinfo.Version=" + version
+		cmd := exec.Command("go", "build", "-ldflags", ldflags, "-o", outputPath, sourcePath)
 
 		cmd.Env = append(os.Environ(),
 			fmt.Sprintf("GOOS=%s", target.GOOS),
@@ -68,3 +71,15 @@ func main() {
 
 	log.Println("All builds completed successfully!")
 }
+
+// buildVersion returns the version from the VERSION environment variable or git describe
+func buildVersion() string {
+	if version := strings.TrimSpace(os.Getenv("VERSION")); version != "" {
+		return version
+	}
+	output, err := exec.Command("git", "describe", "--tags", "--always", "--dirty").Output()
+	if err != nil {
+		return "dev"
+	}
+	return strings.TrimSpace(string(output))
+}